@@ -1,6 +1,7 @@
 package catalog
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
@@ -9,6 +10,7 @@ import (
 	"path/filepath"
 
 	"ordo/internal/domain"
+	"ordo/internal/jsonedit"
 	"ordo/internal/ports"
 )
 
@@ -22,78 +24,136 @@ func NewManifestStore(root string, fs ports.ConfigStore) ManifestStore {
 }
 
 func (s ManifestStore) RewriteCatalogReferences(_ context.Context, targetDir string, catalogName string, packages []string) error {
-	return s.rewriteCatalogReferences(targetDir, catalogName, packages, true)
+	return s.rewriteCatalogReferences(targetDir, domain.SupportedPresetBuckets(), catalogName, packages, true)
 }
 
 func (s ManifestStore) RewriteCatalogReferencesExistingOnly(_ context.Context, targetDir string, catalogName string, packages []string) error {
-	return s.rewriteCatalogReferences(targetDir, catalogName, packages, false)
+	return s.rewriteCatalogReferences(targetDir, domain.SupportedPresetBuckets(), catalogName, packages, false)
 }
 
-func (s ManifestStore) rewriteCatalogReferences(targetDir string, catalogName string, packages []string, addMissing bool) error {
-	path := filepath.Join(s.root, targetDir, "package.json")
-	content, err := s.fs.ReadFile(path)
+func (s ManifestStore) RewriteSectionCatalogReferences(_ context.Context, targetDir string, section domain.PresetBucket, catalogName string, packages []string) error {
+	return s.rewriteCatalogReferences(targetDir, []string{string(section)}, catalogName, packages, true)
+}
+
+func (s ManifestStore) RewriteSectionCatalogReferencesExistingOnly(_ context.Context, targetDir string, section domain.PresetBucket, catalogName string, packages []string) error {
+	return s.rewriteCatalogReferences(targetDir, []string{string(section)}, catalogName, packages, false)
+}
+
+// rewriteCatalogReferences points packages in the given sections at the
+// catalog. With addMissing, a package found in none of them is added to the
+// first section.
+func (s ManifestStore) rewriteCatalogReferences(targetDir string, sections []string, catalogName string, packages []string, addMissing bool) error {
+	path, content, manifest, doc, err := s.loadManifest(targetDir)
 	if err != nil {
-		if errors.Is(err, os.ErrNotExist) {
-			return fmt.Errorf("package manifest not found: %s", path)
-		}
 		return err
 	}
 
-	manifest := map[string]any{}
-	if err := json.Unmarshal(content, &manifest); err != nil {
-		return fmt.Errorf("parse %s: %w", path, err)
-	}
-
-	dependencies := anyToManifestMap(manifest["dependencies"])
-	devDependencies := anyToManifestMap(manifest["devDependencies"])
-	peerDependencies := anyToManifestMap(manifest["peerDependencies"])
-	optionalDependencies := anyToManifestMap(manifest["optionalDependencies"])
-
 	ref := domain.CatalogReference(catalogName)
 	for _, pkg := range packages {
 		updated := false
-		if rewriteDependency(dependencies, pkg, ref) {
-			updated = true
-		}
-		if rewriteDependency(devDependencies, pkg, ref) {
-			updated = true
-		}
-		if rewriteDependency(peerDependencies, pkg, ref) {
-			updated = true
-		}
-		if rewriteDependency(optionalDependencies, pkg, ref) {
+		for _, field := range sections {
+			deps := anyToManifestMap(manifest[field])
+			if _, ok := deps[pkg]; !ok {
+				continue
+			}
 			updated = true
+			if deps[pkg] == ref {
+				continue
+			}
+			if err := doc.Set([]string{field, pkg}, ref); err != nil {
+				return fmt.Errorf("update %s: %w", path, err)
+			}
 		}
 		if addMissing && !updated {
-			dependencies[pkg] = ref
+			if err := doc.Set([]string{sections[0], pkg}, ref); err != nil {
+				return fmt.Errorf("update %s: %w", path, err)
+			}
+		}
+	}
+
+	return s.writeManifest(path, content, doc)
+}
+
+// InlineCatalogReferences replaces catalog references with concrete ranges.
+// versions maps a catalog name, empty for the default catalog, to its package
+// versions; references to anything not listed are left untouched.
+func (s ManifestStore) InlineCatalogReferences(_ context.Context, targetDir string, versions map[string]map[string]string) error {
+	path, content, manifest, doc, err := s.loadManifest(targetDir)
+	if err != nil {
+		return err
+	}
+
+	for _, field := range domain.SupportedPresetBuckets() {
+		for pkg, value := range anyToManifestMap(manifest[field]) {
+			name, ok := domain.ParseCatalogReference(value)
+			if !ok {
+				continue
+			}
+			version, ok := versions[name][pkg]
+			if !ok {
+				continue
+			}
+			if err := doc.Set([]string{field, pkg}, version); err != nil {
+				return fmt.Errorf("update %s: %w", path, err)
+			}
 		}
 	}
 
-	manifest["dependencies"] = dependencies
-	if len(devDependencies) > 0 {
-		manifest["devDependencies"] = devDependencies
+	return s.writeManifest(path, content, doc)
+}
+
+// RenameCatalogReferences points every catalog:<oldName> reference at
+// catalog:<newName>.
+func (s ManifestStore) RenameCatalogReferences(_ context.Context, targetDir string, oldName string, newName string) error {
+	path, content, manifest, doc, err := s.loadManifest(targetDir)
+	if err != nil {
+		return err
+	}
+
+	ref := domain.CatalogReference(newName)
+	for _, field := range domain.SupportedPresetBuckets() {
+		for pkg, value := range anyToManifestMap(manifest[field]) {
+			name, ok := domain.ParseCatalogReference(value)
+			if !ok || name != oldName {
+				continue
+			}
+			if err := doc.Set([]string{field, pkg}, ref); err != nil {
+				return fmt.Errorf("update %s: %w", path, err)
+			}
+		}
 	}
-	if len(peerDependencies) > 0 {
-		manifest["peerDependencies"] = peerDependencies
+
+	return s.writeManifest(path, content, doc)
+}
+
+func (s ManifestStore) loadManifest(targetDir string) (string, []byte, map[string]any, *jsonedit.Document, error) {
+	path := filepath.Join(s.root, targetDir, "package.json")
+	content, err := s.fs.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return "", nil, nil, nil, fmt.Errorf("package manifest not found: %s", path)
+		}
+		return "", nil, nil, nil, err
 	}
-	if len(optionalDependencies) > 0 {
-		manifest["optionalDependencies"] = optionalDependencies
+
+	manifest := map[string]any{}
+	if err := json.Unmarshal(content, &manifest); err != nil {
+		return "", nil, nil, nil, fmt.Errorf("parse %s: %w", path, err)
 	}
 
-	formatted, err := json.MarshalIndent(manifest, "", "  ")
+	doc, err := jsonedit.Parse(content)
 	if err != nil {
-		return fmt.Errorf("marshal %s: %w", path, err)
+		return "", nil, nil, nil, fmt.Errorf("parse %s: %w", path, err)
 	}
-	formatted = append(formatted, '\n')
-	return s.fs.WriteFile(path, formatted, 0o644)
+	return path, content, manifest, doc, nil
 }
 
-func rewriteDependency(deps map[string]string, pkg string, ref string) bool {
-	if _, ok := deps[pkg]; !ok {
-		return false
+func (s ManifestStore) writeManifest(path string, original []byte, doc *jsonedit.Document) error {
+	formatted := doc.Bytes()
+	if bytes.Equal(formatted, original) {
+		return nil
 	}
-	deps[pkg] = ref
-	return true
+	return s.fs.WriteFile(path, formatted, 0o644)
 }
 
 func anyToManifestMap(value any) map[string]string {
@@ -8,6 +8,7 @@ import (
 	"testing"
 
 	fsadapter "ordo/internal/adapters/fs"
+	"ordo/internal/domain"
 )
 
 func TestManifestStoreRewriteCatalogReferencesExistingOnly(t *testing.T) {
@@ -76,6 +77,42 @@ func TestManifestStoreRewriteCatalogReferencesAddsMissing(t *testing.T) {
 	}
 }
 
+func TestManifestStoreRewriteSectionCatalogReferences(t *testing.T) {
+	root := t.TempDir()
+	path := filepath.Join(root, "package.json")
+	content := []byte(`{
+  "devDependencies": { "react": "^19.0.0" },
+  "peerDependencies": { "react": ">=17" }
+}
+`)
+	if err := os.WriteFile(path, content, 0o644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	store := NewManifestStore(root, fsadapter.NewConfigStore())
+	ctx := context.Background()
+	if err := store.RewriteSectionCatalogReferencesExistingOnly(ctx, ".", domain.BucketDevDependencies, "", []string{"react", "zod"}); err != nil {
+		t.Fatalf("RewriteSectionCatalogReferencesExistingOnly() error = %v", err)
+	}
+	if err := store.RewriteSectionCatalogReferences(ctx, ".", domain.BucketOptionalDependencies, "tools", []string{"fsevents"}); err != nil {
+		t.Fatalf("RewriteSectionCatalogReferences() error = %v", err)
+	}
+
+	manifest := readManifest(t, path)
+	if dev := asStringMap(t, manifest["devDependencies"]); dev["react"] != "catalog:" || len(dev) != 1 {
+		t.Fatalf("devDependencies = %#v", dev)
+	}
+	if peer := asStringMap(t, manifest["peerDependencies"]); peer["react"] != ">=17" {
+		t.Fatalf("peerDependencies = %#v, want the peer range kept", peer)
+	}
+	if optional := asStringMap(t, manifest["optionalDependencies"]); optional["fsevents"] != "catalog:tools" {
+		t.Fatalf("optionalDependencies = %#v", optional)
+	}
+	if _, ok := manifest["dependencies"]; ok {
+		t.Fatalf("dependencies should not be added: %#v", manifest)
+	}
+}
+
 func readManifest(t *testing.T, path string) map[string]any {
 	t.Helper()
 	content, err := os.ReadFile(path)
@@ -105,3 +142,108 @@ func asStringMap(t *testing.T, raw any) map[string]string {
 	}
 	return out
 }
+
+func TestManifestStoreRewritePreservesFormatting(t *testing.T) {
+	root := t.TempDir()
+	path := filepath.Join(root, "apps/web/package.json")
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatalf("MkdirAll() error = %v", err)
+	}
+
+	content := "{\r\n\t\"name\": \"web\",\r\n\t\"version\": \"1.0.0\",\r\n\t\"scripts\": {\r\n\t\t\"dev\": \"vite\"\r\n\t},\r\n\t\"dependencies\": {\r\n\t\t\"react\": \"^18.0.0\",\r\n\t\t\"vite\": \"^5.0.0\"\r\n\t}\r\n}\r\n"
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	store := NewManifestStore(root, fsadapter.NewConfigStore())
+	if err := store.RewriteCatalogReferences(context.Background(), "apps/web", "", []string{"react", "zod"}); err != nil {
+		t.Fatalf("RewriteCatalogReferences() error = %v", err)
+	}
+
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	want := "{\r\n\t\"name\": \"web\",\r\n\t\"version\": \"1.0.0\",\r\n\t\"scripts\": {\r\n\t\t\"dev\": \"vite\"\r\n\t},\r\n\t\"dependencies\": {\r\n\t\t\"react\": \"catalog:\",\r\n\t\t\"vite\": \"^5.0.0\",\r\n\t\t\"zod\": \"catalog:\"\r\n\t}\r\n}\r\n"
+	if string(got) != want {
+		t.Fatalf("package.json =\n%q\nwant\n%q", got, want)
+	}
+}
+
+func TestManifestStoreInlineCatalogReferences(t *testing.T) {
+	root := t.TempDir()
+	path := filepath.Join(root, "package.json")
+	content := []byte(`{
+  "name": "root",
+  "dependencies": {
+    "react": "catalog:",
+    "react-dom": "catalog:react17",
+    "zod": "catalog:default"
+  },
+  "devDependencies": {
+    "typescript": "catalog:",
+    "vitest": "^2.0.0"
+  }
+}
+`)
+	if err := os.WriteFile(path, content, 0o644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	store := NewManifestStore(root, fsadapter.NewConfigStore())
+	err := store.InlineCatalogReferences(context.Background(), ".", map[string]map[string]string{
+		"":        {"react": "^19.0.0", "zod": "^3.23.8"},
+		"react17": {"react-dom": "^17.0.2"},
+	})
+	if err != nil {
+		t.Fatalf("InlineCatalogReferences() error = %v", err)
+	}
+
+	assertFileContent(t, path, `{
+  "name": "root",
+  "dependencies": {
+    "react": "^19.0.0",
+    "react-dom": "^17.0.2",
+    "zod": "^3.23.8"
+  },
+  "devDependencies": {
+    "typescript": "catalog:",
+    "vitest": "^2.0.0"
+  }
+}
+`)
+}
+
+func TestManifestStoreRenameCatalogReferences(t *testing.T) {
+	root := t.TempDir()
+	path := filepath.Join(root, "package.json")
+	content := []byte(`{
+  "dependencies": {
+    "react": "catalog:legacy",
+    "zod": "catalog:"
+  },
+  "peerDependencies": {
+    "react-dom": "catalog:legacy"
+  }
+}
+`)
+	if err := os.WriteFile(path, content, 0o644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	store := NewManifestStore(root, fsadapter.NewConfigStore())
+	if err := store.RenameCatalogReferences(context.Background(), ".", "legacy", "react17"); err != nil {
+		t.Fatalf("RenameCatalogReferences() error = %v", err)
+	}
+
+	assertFileContent(t, path, `{
+  "dependencies": {
+    "react": "catalog:react17",
+    "zod": "catalog:"
+  },
+  "peerDependencies": {
+    "react-dom": "catalog:react17"
+  }
+}
+`)
+}
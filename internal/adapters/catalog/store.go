@@ -1,6 +1,7 @@
 package catalog
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
@@ -11,6 +12,7 @@ import (
 	"strings"
 
 	"ordo/internal/domain"
+	"ordo/internal/jsonedit"
 	"ordo/internal/ports"
 
 	"gopkg.in/yaml.v3"
@@ -51,6 +53,36 @@ func (s Store) RemoveCatalogEntries(_ context.Context, manager domain.PackageMan
 	}
 }
 
+// RenameCatalog renames a named catalog in place, keeping its entries and
+// position in the config file.
+func (s Store) RenameCatalog(_ context.Context, manager domain.PackageManager, oldName string, newName string) error {
+	switch manager {
+	case domain.ManagerBun:
+		return s.renameBun(oldName, newName)
+	case domain.ManagerPNPM:
+		return s.renameYAML(filepath.Join(s.root, "pnpm-workspace.yaml"), "catalogs", oldName, newName)
+	case domain.ManagerYarn:
+		return s.renameYAML(filepath.Join(s.root, ".yarnrc.yml"), "npmCatalogs", oldName, newName)
+	default:
+		return fmt.Errorf("catalogs unsupported for package manager: %s", manager)
+	}
+}
+
+// DeleteCatalog removes a named catalog, dropping the catalogs field when no
+// other catalog is left in it.
+func (s Store) DeleteCatalog(_ context.Context, manager domain.PackageManager, name string) error {
+	switch manager {
+	case domain.ManagerBun:
+		return s.deleteBun(name)
+	case domain.ManagerPNPM:
+		return s.deleteYAML(filepath.Join(s.root, "pnpm-workspace.yaml"), "catalogs", name)
+	case domain.ManagerYarn:
+		return s.deleteYAML(filepath.Join(s.root, ".yarnrc.yml"), "npmCatalogs", name)
+	default:
+		return fmt.Errorf("catalogs unsupported for package manager: %s", manager)
+	}
+}
+
 func (s Store) NamedCatalogs(_ context.Context, manager domain.PackageManager) ([]string, error) {
 	switch manager {
 	case domain.ManagerBun:
@@ -64,16 +96,24 @@ func (s Store) NamedCatalogs(_ context.Context, manager domain.PackageManager) (
 	}
 }
 
-func (s Store) CatalogPackageNames(_ context.Context, manager domain.PackageManager, name string) ([]string, error) {
+func (s Store) CatalogPackageNames(ctx context.Context, manager domain.PackageManager, name string) ([]string, error) {
+	entries, err := s.CatalogEntries(ctx, manager, name)
+	if err != nil {
+		return nil, err
+	}
+	return sortedStringMapKeys(entries), nil
+}
+
+func (s Store) CatalogEntries(_ context.Context, manager domain.PackageManager, name string) (map[string]string, error) {
 	switch manager {
 	case domain.ManagerBun:
-		return s.catalogPackageNamesBun(name)
+		return s.catalogEntriesBun(name)
 	case domain.ManagerPNPM:
-		return s.catalogPackageNamesPNPM(name)
+		return s.catalogEntriesPNPM(name)
 	case domain.ManagerYarn:
-		return s.catalogPackageNamesYarn(name)
+		return s.catalogEntriesYarn(name)
 	default:
-		return []string{}, nil
+		return map[string]string{}, nil
 	}
 }
 
@@ -82,95 +122,51 @@ func (s Store) upsertBun(name string, entries map[string]string, force bool) err
 	payload := map[string]any{}
 
 	content, err := s.fs.ReadFile(path)
-	if err != nil {
-		if errors.Is(err, os.ErrNotExist) {
-			payload = map[string]any{}
-		} else {
-			return err
-		}
-	} else if len(content) > 0 {
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	if len(content) > 0 {
 		if err := json.Unmarshal(content, &payload); err != nil {
 			return fmt.Errorf("parse %s: %w", path, err)
 		}
 	}
 
-	if strings.TrimSpace(name) == "" {
-		next, err := upsertStringMap(anyToStringMap(payload["catalog"]), entries, force)
-		if err != nil {
-			return err
-		}
-		payload["catalog"] = next
-	} else {
-		catalogs := anyToStringMapMap(payload["catalogs"])
-		selected := catalogs[name]
-		next, err := upsertStringMap(selected, entries, force)
-		if err != nil {
-			return err
-		}
-		catalogs[name] = next
-		payload["catalogs"] = catalogs
+	field := []string{"catalog"}
+	current := anyToStringMap(payload["catalog"])
+	if strings.TrimSpace(name) != "" {
+		field = []string{"catalogs", name}
+		current = anyToStringMapMap(payload["catalogs"])[name]
 	}
-
-	formatted, err := json.MarshalIndent(payload, "", "  ")
-	if err != nil {
-		return fmt.Errorf("marshal %s: %w", path, err)
+	existing := make(map[string]string, len(current))
+	for pkg, version := range current {
+		existing[pkg] = version
 	}
-	formatted = append(formatted, '\n')
-	return s.fs.WriteFile(path, formatted, 0o644)
-}
-
-func (s Store) upsertPNPM(name string, entries map[string]string, force bool) error {
-	path := filepath.Join(s.root, "pnpm-workspace.yaml")
-	payload, err := s.loadYAML(path)
+	next, err := upsertStringMap(current, entries, force)
 	if err != nil {
 		return err
 	}
 
-	if strings.TrimSpace(name) == "" {
-		next, err := upsertStringMap(anyToStringMap(payload["catalog"]), entries, force)
-		if err != nil {
-			return err
+	doc, err := jsonedit.Parse(content)
+	if err != nil {
+		return fmt.Errorf("parse %s: %w", path, err)
+	}
+	for _, pkg := range sortedStringMapKeys(entries) {
+		if version, ok := existing[pkg]; ok && version == next[pkg] {
+			continue
 		}
-		payload["catalog"] = next
-	} else {
-		catalogs := anyToStringMapMap(payload["catalogs"])
-		selected := catalogs[name]
-		next, err := upsertStringMap(selected, entries, force)
-		if err != nil {
-			return err
+		if err := doc.Set(append(field, pkg), next[pkg]); err != nil {
+			return fmt.Errorf("update %s: %w", path, err)
 		}
-		catalogs[name] = next
-		payload["catalogs"] = catalogs
 	}
+	return s.writeJSON(path, content, doc)
+}
 
-	return s.writeYAML(path, payload)
+func (s Store) upsertPNPM(name string, entries map[string]string, force bool) error {
+	return s.upsertYAML(filepath.Join(s.root, "pnpm-workspace.yaml"), "catalog", "catalogs", name, entries, force)
 }
 
 func (s Store) upsertYarn(name string, entries map[string]string, force bool) error {
-	path := filepath.Join(s.root, ".yarnrc.yml")
-	payload, err := s.loadYAML(path)
-	if err != nil {
-		return err
-	}
-
-	if strings.TrimSpace(name) == "" {
-		next, err := upsertStringMap(anyToStringMap(payload["npmCatalog"]), entries, force)
-		if err != nil {
-			return err
-		}
-		payload["npmCatalog"] = next
-	} else {
-		catalogs := anyToStringMapMap(payload["npmCatalogs"])
-		selected := catalogs[name]
-		next, err := upsertStringMap(selected, entries, force)
-		if err != nil {
-			return err
-		}
-		catalogs[name] = next
-		payload["npmCatalogs"] = catalogs
-	}
-
-	return s.writeYAML(path, payload)
+	return s.upsertYAML(filepath.Join(s.root, ".yarnrc.yml"), "npmCatalog", "npmCatalogs", name, entries, force)
 }
 
 func (s Store) removeBun(name string, packages []string) error {
@@ -191,98 +187,107 @@ func (s Store) removeBun(name string, packages []string) error {
 		return fmt.Errorf("parse %s: %w", path, err)
 	}
 
+	doc, err := jsonedit.Parse(content)
+	if err != nil {
+		return fmt.Errorf("parse %s: %w", path, err)
+	}
+
 	if strings.TrimSpace(name) == "" {
 		next := removeFromStringMap(anyToStringMap(payload["catalog"]), packages)
-		if len(next) == 0 {
-			delete(payload, "catalog")
-		} else {
-			payload["catalog"] = next
+		if err := removeJSONEntries(doc, []string{"catalog"}, packages, len(next) == 0); err != nil {
+			return fmt.Errorf("update %s: %w", path, err)
 		}
 	} else {
 		catalogs := anyToStringMapMap(payload["catalogs"])
 		next := removeFromStringMap(catalogs[name], packages)
+		if err := removeJSONEntries(doc, []string{"catalogs", name}, packages, len(next) == 0); err != nil {
+			return fmt.Errorf("update %s: %w", path, err)
+		}
 		if len(next) == 0 {
 			delete(catalogs, name)
-		} else {
-			catalogs[name] = next
 		}
 		if len(catalogs) == 0 {
-			delete(payload, "catalogs")
-		} else {
-			payload["catalogs"] = catalogs
+			if err := doc.Delete([]string{"catalogs"}); err != nil {
+				return fmt.Errorf("update %s: %w", path, err)
+			}
 		}
 	}
 
-	formatted, err := json.MarshalIndent(payload, "", "  ")
-	if err != nil {
-		return fmt.Errorf("marshal %s: %w", path, err)
-	}
-	formatted = append(formatted, '\n')
-	return s.fs.WriteFile(path, formatted, 0o644)
+	return s.writeJSON(path, content, doc)
 }
 
-func (s Store) removePNPM(name string, packages []string) error {
-	path := filepath.Join(s.root, "pnpm-workspace.yaml")
-	payload, err := s.loadYAML(path)
-	if err != nil {
-		return err
+// removeJSONEntries deletes packages from the object at field, or the whole
+// field when nothing would be left in it.
+func removeJSONEntries(doc *jsonedit.Document, field []string, packages []string, dropField bool) error {
+	if dropField {
+		return doc.Delete(field)
 	}
-
-	if strings.TrimSpace(name) == "" {
-		next := removeFromStringMap(anyToStringMap(payload["catalog"]), packages)
-		if len(next) == 0 {
-			delete(payload, "catalog")
-		} else {
-			payload["catalog"] = next
-		}
-	} else {
-		catalogs := anyToStringMapMap(payload["catalogs"])
-		next := removeFromStringMap(catalogs[name], packages)
-		if len(next) == 0 {
-			delete(catalogs, name)
-		} else {
-			catalogs[name] = next
-		}
-		if len(catalogs) == 0 {
-			delete(payload, "catalogs")
-		} else {
-			payload["catalogs"] = catalogs
+	for _, pkg := range packages {
+		if err := doc.Delete(append(field, pkg)); err != nil {
+			return err
 		}
 	}
+	return nil
+}
 
-	return s.writeYAML(path, payload)
+func (s Store) removePNPM(name string, packages []string) error {
+	return s.removeYAML(filepath.Join(s.root, "pnpm-workspace.yaml"), "catalog", "catalogs", name, packages)
 }
 
 func (s Store) removeYarn(name string, packages []string) error {
-	path := filepath.Join(s.root, ".yarnrc.yml")
-	payload, err := s.loadYAML(path)
+	return s.removeYAML(filepath.Join(s.root, ".yarnrc.yml"), "npmCatalog", "npmCatalogs", name, packages)
+}
+
+func (s Store) renameBun(oldName string, newName string) error {
+	path := filepath.Join(s.root, "package.json")
+	content, err := s.fs.ReadFile(path)
 	if err != nil {
 		return err
 	}
+	doc, err := jsonedit.Parse(content)
+	if err != nil {
+		return fmt.Errorf("parse %s: %w", path, err)
+	}
+	if err := doc.Rename([]string{"catalogs", oldName}, newName); err != nil {
+		return fmt.Errorf("update %s: %w", path, err)
+	}
+	return s.writeJSON(path, content, doc)
+}
 
-	if strings.TrimSpace(name) == "" {
-		next := removeFromStringMap(anyToStringMap(payload["npmCatalog"]), packages)
-		if len(next) == 0 {
-			delete(payload, "npmCatalog")
-		} else {
-			payload["npmCatalog"] = next
-		}
-	} else {
-		catalogs := anyToStringMapMap(payload["npmCatalogs"])
-		next := removeFromStringMap(catalogs[name], packages)
-		if len(next) == 0 {
-			delete(catalogs, name)
-		} else {
-			catalogs[name] = next
-		}
-		if len(catalogs) == 0 {
-			delete(payload, "npmCatalogs")
-		} else {
-			payload["npmCatalogs"] = catalogs
+func (s Store) deleteBun(name string) error {
+	path := filepath.Join(s.root, "package.json")
+	content, err := s.fs.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
 		}
+		return err
 	}
 
-	return s.writeYAML(path, payload)
+	payload := map[string]any{}
+	if err := json.Unmarshal(content, &payload); err != nil {
+		return fmt.Errorf("parse %s: %w", path, err)
+	}
+	catalogs, ok := payload["catalogs"].(map[string]any)
+	if !ok {
+		return nil
+	}
+	if _, ok := catalogs[name]; !ok {
+		return nil
+	}
+
+	doc, err := jsonedit.Parse(content)
+	if err != nil {
+		return fmt.Errorf("parse %s: %w", path, err)
+	}
+	field := []string{"catalogs", name}
+	if len(catalogs) == 1 {
+		field = []string{"catalogs"}
+	}
+	if err := doc.Delete(field); err != nil {
+		return fmt.Errorf("update %s: %w", path, err)
+	}
+	return s.writeJSON(path, content, doc)
 }
 
 func (s Store) namedBunCatalogs() ([]string, error) {
@@ -326,12 +331,12 @@ func (s Store) namedYarnCatalogs() ([]string, error) {
 	return sortedMapKeys(anyToStringMapMap(payload["npmCatalogs"])), nil
 }
 
-func (s Store) catalogPackageNamesBun(name string) ([]string, error) {
+func (s Store) catalogEntriesBun(name string) (map[string]string, error) {
 	path := filepath.Join(s.root, "package.json")
 	content, err := s.fs.ReadFile(path)
 	if err != nil {
 		if errors.Is(err, os.ErrNotExist) {
-			return []string{}, nil
+			return map[string]string{}, nil
 		}
 		return nil, err
 	}
@@ -341,42 +346,51 @@ func (s Store) catalogPackageNamesBun(name string) ([]string, error) {
 		return nil, fmt.Errorf("parse %s: %w", path, err)
 	}
 	if strings.TrimSpace(name) == "" {
-		return sortedStringMapKeys(anyToStringMap(payload["catalog"])), nil
+		return anyToStringMap(payload["catalog"]), nil
 	}
 	catalogs := anyToStringMapMap(payload["catalogs"])
-	return sortedStringMapKeys(catalogs[name]), nil
+	if catalogs[name] == nil {
+		return map[string]string{}, nil
+	}
+	return catalogs[name], nil
 }
 
-func (s Store) catalogPackageNamesPNPM(name string) ([]string, error) {
+func (s Store) catalogEntriesPNPM(name string) (map[string]string, error) {
 	path := filepath.Join(s.root, "pnpm-workspace.yaml")
 	payload, err := s.loadYAML(path)
 	if err != nil {
 		if errors.Is(err, os.ErrNotExist) {
-			return []string{}, nil
+			return map[string]string{}, nil
 		}
 		return nil, err
 	}
 	if strings.TrimSpace(name) == "" {
-		return sortedStringMapKeys(anyToStringMap(payload["catalog"])), nil
+		return anyToStringMap(payload["catalog"]), nil
 	}
 	catalogs := anyToStringMapMap(payload["catalogs"])
-	return sortedStringMapKeys(catalogs[name]), nil
+	if catalogs[name] == nil {
+		return map[string]string{}, nil
+	}
+	return catalogs[name], nil
 }
 
-func (s Store) catalogPackageNamesYarn(name string) ([]string, error) {
+func (s Store) catalogEntriesYarn(name string) (map[string]string, error) {
 	path := filepath.Join(s.root, ".yarnrc.yml")
 	payload, err := s.loadYAML(path)
 	if err != nil {
 		if errors.Is(err, os.ErrNotExist) {
-			return []string{}, nil
+			return map[string]string{}, nil
 		}
 		return nil, err
 	}
 	if strings.TrimSpace(name) == "" {
-		return sortedStringMapKeys(anyToStringMap(payload["npmCatalog"])), nil
+		return anyToStringMap(payload["npmCatalog"]), nil
 	}
 	catalogs := anyToStringMapMap(payload["npmCatalogs"])
-	return sortedStringMapKeys(catalogs[name]), nil
+	if catalogs[name] == nil {
+		return map[string]string{}, nil
+	}
+	return catalogs[name], nil
 }
 
 func (s Store) loadYAML(path string) (map[string]any, error) {
@@ -401,10 +415,10 @@ func (s Store) loadYAML(path string) (map[string]any, error) {
 	return payload, nil
 }
 
-func (s Store) writeYAML(path string, payload map[string]any) error {
-	formatted, err := yaml.Marshal(payload)
-	if err != nil {
-		return fmt.Errorf("marshal %s: %w", path, err)
+func (s Store) writeJSON(path string, original []byte, doc *jsonedit.Document) error {
+	formatted := doc.Bytes()
+	if bytes.Equal(formatted, original) {
+		return nil
 	}
 	return s.fs.WriteFile(path, formatted, 0o644)
 }
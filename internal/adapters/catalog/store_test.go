@@ -0,0 +1,253 @@
+package catalog
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	fsadapter "ordo/internal/adapters/fs"
+	"ordo/internal/domain"
+)
+
+func TestStoreBunCatalogEditsPreserveFormatting(t *testing.T) {
+	root := t.TempDir()
+	path := filepath.Join(root, "package.json")
+	content := "{\n    \"name\": \"repo\",\n    \"workspaces\": [\"packages/*\"],\n    \"catalog\": {\n        \"react\": \"^18.0.0\"\n    }\n}\n"
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	store := NewStore(root, fsadapter.NewConfigStore())
+	ctx := context.Background()
+	if err := store.UpsertCatalogEntries(ctx, domain.ManagerBun, "", map[string]string{"zod": "^3.23.0"}, false); err != nil {
+		t.Fatalf("UpsertCatalogEntries() error = %v", err)
+	}
+	assertFileContent(t, path, "{\n    \"name\": \"repo\",\n    \"workspaces\": [\"packages/*\"],\n    \"catalog\": {\n        \"react\": \"^18.0.0\",\n        \"zod\": \"^3.23.0\"\n    }\n}\n")
+
+	if err := store.RemoveCatalogEntries(ctx, domain.ManagerBun, "", []string{"react", "zod"}); err != nil {
+		t.Fatalf("RemoveCatalogEntries() error = %v", err)
+	}
+	assertFileContent(t, path, "{\n    \"name\": \"repo\",\n    \"workspaces\": [\"packages/*\"]\n}\n")
+}
+
+func assertFileContent(t *testing.T, path string, want string) {
+	t.Helper()
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	if string(got) != want {
+		t.Fatalf("%s =\n%q\nwant\n%q", filepath.Base(path), got, want)
+	}
+}
+
+func TestStorePNPMCatalogEditsPreserveLayout(t *testing.T) {
+	root := t.TempDir()
+	path := filepath.Join(root, "pnpm-workspace.yaml")
+	content := `# Workspace layout
+packages:
+  - apps/*
+  - packages/*
+
+catalog:
+  # UI stack
+  react: '^18.3.0'
+  react-dom: '^18.3.0' # keep in sync with react
+
+catalogs:
+  legacy: &legacy
+    react: '^17.0.2'
+`
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	store := NewStore(root, fsadapter.NewConfigStore())
+	ctx := context.Background()
+	if err := store.UpsertCatalogEntries(ctx, domain.ManagerPNPM, "", map[string]string{"zod": "^3.23.0", "react": "^18.3.1"}, true); err != nil {
+		t.Fatalf("UpsertCatalogEntries() error = %v", err)
+	}
+	assertFileContent(t, path, `# Workspace layout
+packages:
+  - apps/*
+  - packages/*
+
+catalog:
+  # UI stack
+  react: '^18.3.1'
+  react-dom: '^18.3.0' # keep in sync with react
+  zod: '^3.23.0'
+
+catalogs:
+  legacy: &legacy
+    react: '^17.0.2'
+`)
+
+	if err := store.RemoveCatalogEntries(ctx, domain.ManagerPNPM, "legacy", []string{"react"}); err != nil {
+		t.Fatalf("RemoveCatalogEntries() error = %v", err)
+	}
+	assertFileContent(t, path, `# Workspace layout
+packages:
+  - apps/*
+  - packages/*
+
+catalog:
+  # UI stack
+  react: '^18.3.1'
+  react-dom: '^18.3.0' # keep in sync with react
+  zod: '^3.23.0'
+`)
+}
+
+func TestStorePNPMCatalogEditsKeepUntouchedLines(t *testing.T) {
+	root := t.TempDir()
+	path := filepath.Join(root, "pnpm-workspace.yaml")
+	content := "packages:\n- apps/*\n- packages/*\n\n\n\ncatalog:\n    react: ^18.3.0   # pinned\n    \"@types/react\": \"^18.3.0\"\n\n\nonlyBuiltDependencies:\n- esbuild\n"
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	store := NewStore(root, fsadapter.NewConfigStore())
+	ctx := context.Background()
+	if err := store.UpsertCatalogEntries(ctx, domain.ManagerPNPM, "", map[string]string{"react": "^18.3.1", "@types/node": "^22.0.0"}, true); err != nil {
+		t.Fatalf("UpsertCatalogEntries() error = %v", err)
+	}
+	if err := store.UpsertCatalogEntries(ctx, domain.ManagerPNPM, "tools", map[string]string{"typescript": "~5.6.0"}, false); err != nil {
+		t.Fatalf("UpsertCatalogEntries() error = %v", err)
+	}
+	assertFileContent(t, path, "packages:\n- apps/*\n- packages/*\n\n\n\ncatalog:\n    react: ^18.3.1   # pinned\n    \"@types/react\": \"^18.3.0\"\n    \"@types/node\": \"^22.0.0\"\n\n\nonlyBuiltDependencies:\n- esbuild\ncatalogs:\n    tools:\n        typescript: ~5.6.0\n")
+
+	if err := store.RemoveCatalogEntries(ctx, domain.ManagerPNPM, "tools", []string{"typescript"}); err != nil {
+		t.Fatalf("RemoveCatalogEntries() error = %v", err)
+	}
+	if err := store.RemoveCatalogEntries(ctx, domain.ManagerPNPM, "", []string{"@types/react"}); err != nil {
+		t.Fatalf("RemoveCatalogEntries() error = %v", err)
+	}
+	assertFileContent(t, path, "packages:\n- apps/*\n- packages/*\n\n\n\ncatalog:\n    react: ^18.3.1   # pinned\n    \"@types/node\": \"^22.0.0\"\n\n\nonlyBuiltDependencies:\n- esbuild\n")
+}
+
+func TestStorePNPMCatalogRefusesEditsThroughAliases(t *testing.T) {
+	root := t.TempDir()
+	path := filepath.Join(root, "pnpm-workspace.yaml")
+	content := "catalog:\n  react: &react ^18.3.0\n  react-dom: *react\ncatalogs:\n  base: &base\n    zod: ^3.23.0\n  next: *base\n"
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	store := NewStore(root, fsadapter.NewConfigStore())
+	ctx := context.Background()
+	refused := []func() error{
+		func() error {
+			return store.UpsertCatalogEntries(ctx, domain.ManagerPNPM, "", map[string]string{"react": "^19.0.0"}, true)
+		},
+		func() error {
+			return store.UpsertCatalogEntries(ctx, domain.ManagerPNPM, "", map[string]string{"react-dom": "^19.0.0"}, true)
+		},
+		func() error {
+			return store.UpsertCatalogEntries(ctx, domain.ManagerPNPM, "next", map[string]string{"valibot": "^1.0.0"}, false)
+		},
+		func() error {
+			return store.UpsertCatalogEntries(ctx, domain.ManagerPNPM, "base", map[string]string{"valibot": "^1.0.0"}, false)
+		},
+		func() error {
+			return store.RemoveCatalogEntries(ctx, domain.ManagerPNPM, "", []string{"react"})
+		},
+	}
+	for i, edit := range refused {
+		if err := edit(); err == nil || !strings.Contains(err.Error(), "anchor") {
+			t.Fatalf("edit %d error = %v, want an alias refusal", i, err)
+		}
+		assertFileContent(t, path, content)
+	}
+
+	if err := store.UpsertCatalogEntries(ctx, domain.ManagerPNPM, "", map[string]string{"zod": "^3.23.0"}, false); err != nil {
+		t.Fatalf("UpsertCatalogEntries() error = %v", err)
+	}
+	if err := store.RemoveCatalogEntries(ctx, domain.ManagerPNPM, "", []string{"react-dom"}); err != nil {
+		t.Fatalf("RemoveCatalogEntries() error = %v", err)
+	}
+	assertFileContent(t, path, "catalog:\n  react: &react ^18.3.0\n  zod: ^3.23.0\ncatalogs:\n  base: &base\n    zod: ^3.23.0\n  next: *base\n")
+}
+
+func TestStoreYarnCatalogConflict(t *testing.T) {
+	root := t.TempDir()
+	path := filepath.Join(root, ".yarnrc.yml")
+	if err := os.WriteFile(path, []byte("nodeLinker: node-modules\nnpmCatalog:\n  react: ^18.3.0\n"), 0o644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	store := NewStore(root, fsadapter.NewConfigStore())
+	err := store.UpsertCatalogEntries(context.Background(), domain.ManagerYarn, "", map[string]string{"react": "^19.0.0"}, false)
+	if err == nil || !strings.Contains(err.Error(), "catalog conflict for react") {
+		t.Fatalf("UpsertCatalogEntries() error = %v, want catalog conflict", err)
+	}
+	assertFileContent(t, path, "nodeLinker: node-modules\nnpmCatalog:\n  react: ^18.3.0\n")
+
+	if err := store.UpsertCatalogEntries(context.Background(), domain.ManagerYarn, "react19", map[string]string{"react": "^19.0.0"}, false); err != nil {
+		t.Fatalf("UpsertCatalogEntries() error = %v", err)
+	}
+	assertFileContent(t, path, "nodeLinker: node-modules\nnpmCatalog:\n  react: ^18.3.0\nnpmCatalogs:\n  react19:\n    react: ^19.0.0\n")
+}
+
+func TestStoreRenameCatalogKeepsLayout(t *testing.T) {
+	root := t.TempDir()
+	ctx := context.Background()
+	store := NewStore(root, fsadapter.NewConfigStore())
+
+	yamlPath := filepath.Join(root, "pnpm-workspace.yaml")
+	content := "catalogs:\n  # pinned for the old admin\n  legacy: &legacy\n    react: '^17.0.2'\n  tools:\n    typescript: ^5.6.0\n"
+	if err := os.WriteFile(yamlPath, []byte(content), 0o644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	if err := store.RenameCatalog(ctx, domain.ManagerPNPM, "legacy", "react17"); err != nil {
+		t.Fatalf("RenameCatalog() error = %v", err)
+	}
+	assertFileContent(t, yamlPath, "catalogs:\n  # pinned for the old admin\n  react17: &legacy\n    react: '^17.0.2'\n  tools:\n    typescript: ^5.6.0\n")
+	if err := store.RenameCatalog(ctx, domain.ManagerPNPM, "react17", "tools"); err == nil {
+		t.Fatal("RenameCatalog() onto existing catalog error = nil, want non-nil")
+	}
+
+	jsonPath := filepath.Join(root, "package.json")
+	if err := os.WriteFile(jsonPath, []byte("{\n  \"catalogs\": {\n    \"legacy\": {\n      \"react\": \"^17.0.2\"\n    }\n  }\n}\n"), 0o644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	if err := store.RenameCatalog(ctx, domain.ManagerBun, "legacy", "react17"); err != nil {
+		t.Fatalf("RenameCatalog() error = %v", err)
+	}
+	assertFileContent(t, jsonPath, "{\n  \"catalogs\": {\n    \"react17\": {\n      \"react\": \"^17.0.2\"\n    }\n  }\n}\n")
+}
+
+func TestStoreDeleteCatalogRemovesEmptyCatalogs(t *testing.T) {
+	root := t.TempDir()
+	ctx := context.Background()
+	store := NewStore(root, fsadapter.NewConfigStore())
+
+	yamlPath := filepath.Join(root, "pnpm-workspace.yaml")
+	content := "catalog:\n  react: ^19.0.0\ncatalogs:\n  empty: {}\n  tools:\n    typescript: ^5.6.0\n"
+	if err := os.WriteFile(yamlPath, []byte(content), 0o644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	if err := store.DeleteCatalog(ctx, domain.ManagerPNPM, "empty"); err != nil {
+		t.Fatalf("DeleteCatalog() error = %v", err)
+	}
+	assertFileContent(t, yamlPath, "catalog:\n  react: ^19.0.0\ncatalogs:\n  tools:\n    typescript: ^5.6.0\n")
+	if err := store.DeleteCatalog(ctx, domain.ManagerPNPM, "tools"); err != nil {
+		t.Fatalf("DeleteCatalog() error = %v", err)
+	}
+	assertFileContent(t, yamlPath, "catalog:\n  react: ^19.0.0\n")
+
+	jsonPath := filepath.Join(root, "package.json")
+	if err := os.WriteFile(jsonPath, []byte("{\n  \"name\": \"repo\",\n  \"catalogs\": {\n    \"empty\": {},\n    \"tools\": {\n      \"typescript\": \"^5.6.0\"\n    }\n  }\n}\n"), 0o644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	if err := store.DeleteCatalog(ctx, domain.ManagerBun, "empty"); err != nil {
+		t.Fatalf("DeleteCatalog() error = %v", err)
+	}
+	assertFileContent(t, jsonPath, "{\n  \"name\": \"repo\",\n  \"catalogs\": {\n    \"tools\": {\n      \"typescript\": \"^5.6.0\"\n    }\n  }\n}\n")
+	if err := store.DeleteCatalog(ctx, domain.ManagerBun, "tools"); err != nil {
+		t.Fatalf("DeleteCatalog() error = %v", err)
+	}
+	assertFileContent(t, jsonPath, "{\n  \"name\": \"repo\"\n}\n")
+}
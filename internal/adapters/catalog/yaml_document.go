@@ -0,0 +1,584 @@
+package catalog
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"gopkg.in/yaml.v3"
+)
+
+// upsertYAML writes entries into the default catalog (field) or a named one
+// (namedField.name). Only the lines it changes are rewritten, so comments, key
+// order, anchors, quoting, and blank lines elsewhere in the file survive.
+func (s Store) upsertYAML(path string, field string, namedField string, name string, entries map[string]string, force bool) error {
+	src, err := s.loadYAMLSource(path)
+	if err != nil {
+		return err
+	}
+
+	keys := catalogKeys(field, namedField, name)
+	packages := sortedStringMapKeys(entries)
+	if current := lookupMapping(src.root(), keys...); current != nil {
+		for _, pkg := range packages {
+			existing := mappingValue(current, pkg)
+			if existing != nil && existing.Value != entries[pkg] && !force {
+				return fmt.Errorf("catalog conflict for %s: existing=%s want=%s", pkg, existing.Value, entries[pkg])
+			}
+		}
+	}
+
+	var parentKey *yaml.Node
+	mapping := src.root()
+	for i, key := range keys {
+		k, v := mappingEntry(mapping, key)
+		if v == nil {
+			return src.write(s, src.appendEntries(parentKey, mapping, nestedCatalogLines(keys[i:], entries, packages, src.indent)))
+		}
+		if err := src.editable(keys[:i+1], v); err != nil {
+			return err
+		}
+		if v.Kind == yaml.ScalarNode && v.Tag == "!!null" {
+			return src.write(s, src.fillNull(k, v, nestedCatalogLines(keys[i+1:], entries, packages, src.indent)))
+		}
+		if v.Kind != yaml.MappingNode {
+			return fmt.Errorf("edit %s: %s is not a mapping", path, strings.Join(keys[:i+1], "."))
+		}
+		parentKey, mapping = k, v
+	}
+
+	var keyStyle, valueStyle yaml.Style
+	if n := len(mapping.Content); n >= 2 {
+		keyStyle = mapping.Content[n-2].Style
+		valueStyle = mapping.Content[n-1].Style
+	}
+	missing := []string{}
+	for _, pkg := range packages {
+		_, v := mappingEntry(mapping, pkg)
+		if v == nil {
+			missing = append(missing, yamlScalar(pkg, keyStyle)+": "+yamlScalar(entries[pkg], valueStyle))
+			continue
+		}
+		if err := src.editable(append(slices.Clone(keys), pkg), v); err != nil {
+			return err
+		}
+		if v.Kind != yaml.ScalarNode {
+			return fmt.Errorf("edit %s: %s.%s is not a scalar", path, strings.Join(keys, "."), pkg)
+		}
+		if v.Value != entries[pkg] {
+			if err := src.replaceScalar(v, yamlScalar(entries[pkg], v.Style)); err != nil {
+				return err
+			}
+		}
+	}
+	if len(missing) > 0 {
+		if err := src.appendEntries(parentKey, mapping, missing); err != nil {
+			return err
+		}
+	}
+	return src.write(s, nil)
+}
+
+// removeYAML deletes packages from a catalog, dropping catalogs left empty.
+func (s Store) removeYAML(path string, field string, namedField string, name string, packages []string) error {
+	src, err := s.loadYAMLSource(path)
+	if err != nil {
+		return err
+	}
+
+	keys := catalogKeys(field, namedField, name)
+	entries := []yamlEntry{}
+	mapping := src.root()
+	for i, key := range keys {
+		k, v := mappingEntry(mapping, key)
+		if v == nil || resolveAlias(v).Kind != yaml.MappingNode {
+			return nil
+		}
+		if err := src.editable(keys[:i+1], v); err != nil {
+			return err
+		}
+		entries = append(entries, yamlEntry{key: k, value: v, parent: mapping})
+		mapping = v
+	}
+
+	removed := []yamlEntry{}
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		k, v := mapping.Content[i], mapping.Content[i+1]
+		if !slices.Contains(packages, k.Value) {
+			continue
+		}
+		removed = append(removed, yamlEntry{key: k, value: v, parent: mapping})
+	}
+	if len(removed) == 0 {
+		return nil
+	}
+
+	if len(removed)*2 == len(mapping.Content) {
+		// The catalog is left empty: drop it, and its parent when that is
+		// left empty too.
+		drop := entries[len(entries)-1]
+		if len(entries) > 1 && len(entries[0].value.Content) == 2 {
+			drop = entries[0]
+		}
+		removed = []yamlEntry{drop}
+	}
+	for _, entry := range removed {
+		if containsAliasTarget(entry.value, src.aliased) {
+			return fmt.Errorf("edit %s: %s.%s holds an anchor other entries alias; edit it by hand", path, strings.Join(keys, "."), entry.key.Value)
+		}
+		if err := src.deleteEntry(entry); err != nil {
+			return err
+		}
+	}
+	return src.write(s, nil)
+}
+
+// renameYAML renames the namedField.oldName mapping key, so its anchor,
+// comments, and entries stay where they are.
+func (s Store) renameYAML(path string, namedField string, oldName string, newName string) error {
+	src, err := s.loadYAMLSource(path)
+	if err != nil {
+		return err
+	}
+
+	catalogs := lookupMapping(src.root(), namedField)
+	if catalogs == nil {
+		return nil
+	}
+	if mappingValue(catalogs, newName) != nil {
+		return fmt.Errorf("catalog %s already exists in %s", newName, path)
+	}
+	for i := 0; i+1 < len(catalogs.Content); i += 2 {
+		if key := catalogs.Content[i]; key.Value == oldName {
+			if err := src.replaceScalar(key, yamlScalar(newName, key.Style)); err != nil {
+				return err
+			}
+			break
+		}
+	}
+	return src.write(s, nil)
+}
+
+// deleteYAML drops the namedField.name catalog, and namedField itself when no
+// other catalog is left in it.
+func (s Store) deleteYAML(path string, namedField string, name string) error {
+	src, err := s.loadYAMLSource(path)
+	if err != nil {
+		return err
+	}
+
+	root := src.root()
+	fieldKey, catalogs := mappingEntry(root, namedField)
+	if catalogs == nil || resolveAlias(catalogs).Kind != yaml.MappingNode {
+		return nil
+	}
+	if err := src.editable([]string{namedField}, catalogs); err != nil {
+		return err
+	}
+	key, value := mappingEntry(catalogs, name)
+	if value == nil {
+		return nil
+	}
+
+	entry := yamlEntry{key: key, value: value, parent: catalogs}
+	if len(catalogs.Content) == 2 {
+		entry = yamlEntry{key: fieldKey, value: catalogs, parent: root}
+	}
+	if containsAliasTarget(entry.value, src.aliased) {
+		return fmt.Errorf("edit %s: %s.%s holds an anchor other entries alias; edit it by hand", path, namedField, name)
+	}
+	if err := src.deleteEntry(entry); err != nil {
+		return err
+	}
+	return src.write(s, nil)
+}
+
+func catalogKeys(field string, namedField string, name string) []string {
+	if strings.TrimSpace(name) == "" {
+		return []string{field}
+	}
+	return []string{namedField, name}
+}
+
+// yamlSource is a YAML document and its text. Edits are collected as byte
+// ranges of the original text and applied together, so untouched lines are
+// written back exactly as they were read.
+type yamlSource struct {
+	path    string
+	doc     *yaml.Node
+	src     []byte
+	lines   []int
+	newline string
+	indent  int
+	aliased map[*yaml.Node]bool
+	edits   []yamlEdit
+}
+
+// yamlEdit replaces src[start:end] with text.
+type yamlEdit struct {
+	start int
+	end   int
+	text  string
+}
+
+type yamlEntry struct {
+	key    *yaml.Node
+	value  *yaml.Node
+	parent *yaml.Node
+}
+
+func (s Store) loadYAMLSource(path string) (*yamlSource, error) {
+	content, err := s.fs.ReadFile(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+
+	doc := &yaml.Node{}
+	if len(bytes.TrimSpace(content)) > 0 {
+		if err := yaml.Unmarshal(content, doc); err != nil {
+			return nil, fmt.Errorf("parse %s: %w", path, err)
+		}
+	}
+	if doc.Kind != yaml.DocumentNode || len(doc.Content) == 0 {
+		doc = &yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode, Tag: "!!map"}}}
+	}
+	if doc.Content[0].Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("parse %s: top level is not a mapping", path)
+	}
+	if doc.Content[0].Style&yaml.FlowStyle != 0 {
+		return nil, fmt.Errorf("edit %s: top level is a flow mapping", path)
+	}
+
+	src := &yamlSource{
+		path:    path,
+		doc:     doc,
+		src:     content,
+		lines:   []int{0},
+		newline: "\n",
+		indent:  detectYAMLIndent(content),
+		aliased: map[*yaml.Node]bool{},
+	}
+	for i, b := range content {
+		if b == '\n' && i+1 < len(content) {
+			src.lines = append(src.lines, i+1)
+		}
+	}
+	if bytes.Contains(content, []byte("\r\n")) {
+		src.newline = "\r\n"
+	}
+	markAliasTargets(doc, src.aliased)
+	return src, nil
+}
+
+func (y *yamlSource) root() *yaml.Node {
+	return y.doc.Content[0]
+}
+
+// editable refuses to edit a node shared through a YAML anchor: changing an
+// alias or its anchored value in place would change every other alias too.
+func (y *yamlSource) editable(keys []string, node *yaml.Node) error {
+	if node.Kind == yaml.AliasNode || y.aliased[node] {
+		return fmt.Errorf("edit %s: %s is shared through a YAML anchor; edit it by hand", y.path, strings.Join(keys, "."))
+	}
+	return nil
+}
+
+// write applies the collected edits, unless err is set.
+func (y *yamlSource) write(s Store, err error) error {
+	if err != nil {
+		return err
+	}
+	if len(y.edits) == 0 {
+		return nil
+	}
+	edits := slices.Clone(y.edits)
+	slices.SortFunc(edits, func(a, b yamlEdit) int { return b.start - a.start })
+
+	out := slices.Clone(y.src)
+	for _, edit := range edits {
+		out = slices.Concat(out[:edit.start], []byte(edit.text), out[edit.end:])
+	}
+	if bytes.Equal(out, y.src) {
+		return nil
+	}
+	return s.fs.WriteFile(y.path, out, 0o644)
+}
+
+// replaceScalar swaps the text of a block-context scalar for text.
+func (y *yamlSource) replaceScalar(node *yaml.Node, text string) error {
+	if node.Style&(yaml.LiteralStyle|yaml.FoldedStyle) != 0 {
+		return fmt.Errorf("edit %s: line %d holds a block scalar", y.path, node.Line)
+	}
+	start, end := y.scalarSpan(node)
+	y.edits = append(y.edits, yamlEdit{start: start, end: end, text: text})
+	return nil
+}
+
+// appendEntries adds lines, indented relative to each other, after the last
+// entry of mapping. parentKey is the key mapping belongs to, nil for the root.
+func (y *yamlSource) appendEntries(parentKey *yaml.Node, mapping *yaml.Node, lines []string) error {
+	if mapping.Style&yaml.FlowStyle != 0 {
+		if len(mapping.Content) > 0 || parentKey == nil {
+			return fmt.Errorf("edit %s: line %d holds a flow mapping", y.path, mapping.Line)
+		}
+		return y.fillNull(parentKey, mapping, lines)
+	}
+	if len(mapping.Content) == 0 {
+		y.insertLines(len(y.src), 0, lines)
+		return nil
+	}
+	last := len(mapping.Content) - 2
+	end := y.entryEndLine(mapping.Content[last], mapping.Content[last+1])
+	y.insertLines(y.lineEnd(end), mapping.Content[0].Column-1, lines)
+	return nil
+}
+
+// fillNull turns an empty value ("key:", "key: ~", or "key: {}") into a block
+// mapping holding lines.
+func (y *yamlSource) fillNull(key *yaml.Node, value *yaml.Node, lines []string) error {
+	if key.Line != value.Line {
+		return fmt.Errorf("edit %s: line %d holds an empty value on its own line", y.path, value.Line)
+	}
+	start := y.offset(value.Line, value.Column)
+	end := start
+	if value.Kind == yaml.MappingNode {
+		end = start + bytes.IndexByte(y.src[start:], '}') + 1
+	} else {
+		_, end = y.scalarSpan(value)
+	}
+	for start > 0 && y.src[start-1] == ' ' {
+		start--
+	}
+	y.edits = append(y.edits, yamlEdit{start: start, end: end})
+	y.insertLines(y.lineEnd(key.Line), key.Column-1+y.indent, lines)
+	return nil
+}
+
+// deleteEntry removes an entry with its head comment. Blank lines before it
+// go too when nothing but blank lines or the end of the file follows, so
+// block separators stay single.
+func (y *yamlSource) deleteEntry(entry yamlEntry) error {
+	if entry.parent.Style&yaml.FlowStyle != 0 {
+		return fmt.Errorf("edit %s: line %d holds a flow mapping", y.path, entry.parent.Line)
+	}
+	first := entry.key.Line
+	end := y.entryEndLine(entry.key, entry.value)
+	if entry.key.HeadComment != "" {
+		for first > 1 && strings.HasPrefix(strings.TrimSpace(y.lineText(first-1)), "#") {
+			first--
+		}
+	}
+	if end == len(y.lines) || y.blank(end+1) {
+		for first > 1 && y.blank(first-1) {
+			first--
+		}
+	}
+	y.edits = append(y.edits, yamlEdit{start: y.lines[first-1], end: y.lineEnd(end)})
+	return nil
+}
+
+func (y *yamlSource) insertLines(at int, indent int, lines []string) {
+	prefix := strings.Repeat(" ", indent)
+	var text strings.Builder
+	if at == len(y.src) && at > 0 && y.src[at-1] != '\n' {
+		text.WriteString(y.newline)
+	}
+	for _, line := range lines {
+		text.WriteString(prefix + line + y.newline)
+	}
+	y.edits = append(y.edits, yamlEdit{start: at, end: at, text: text.String()})
+}
+
+// entryEndLine returns the last line of a block mapping entry: the key line
+// and every following line indented deeper, or sequence items at the key's
+// own indentation.
+func (y *yamlSource) entryEndLine(key *yaml.Node, value *yaml.Node) int {
+	indent := key.Column - 1
+	last := key.Line
+	for line := key.Line + 1; line <= len(y.lines); line++ {
+		text := y.lineText(line)
+		trimmed := strings.TrimLeft(text, " ")
+		if strings.TrimSpace(trimmed) == "" {
+			continue
+		}
+		width := len(text) - len(trimmed)
+		if width > indent || (width == indent && value.Kind == yaml.SequenceNode && strings.HasPrefix(trimmed, "-")) {
+			last = line
+			continue
+		}
+		break
+	}
+	return last
+}
+
+// scalarSpan returns the byte range of a scalar's text, without its anchor or
+// trailing comment.
+func (y *yamlSource) scalarSpan(node *yaml.Node) (int, int) {
+	start := y.offset(node.Line, node.Column)
+	if node.Anchor != "" {
+		start += len("&" + node.Anchor)
+		for start < len(y.src) && y.src[start] == ' ' {
+			start++
+		}
+	}
+	line := y.src[start:y.lineEnd(node.Line)]
+	line = bytes.TrimRight(line, "\r\n")
+	switch {
+	case node.Style&yaml.DoubleQuotedStyle != 0:
+		for i := 1; i < len(line); i++ {
+			if line[i] == '\\' {
+				i++
+			} else if line[i] == '"' {
+				return start, start + i + 1
+			}
+		}
+	case node.Style&yaml.SingleQuotedStyle != 0:
+		for i := 1; i < len(line); i++ {
+			if line[i] != '\'' {
+				continue
+			}
+			if i+1 < len(line) && line[i+1] == '\'' {
+				i++
+				continue
+			}
+			return start, start + i + 1
+		}
+	}
+	for _, stop := range []string{" #", ": ", "\t#"} {
+		if i := bytes.Index(line, []byte(stop)); i >= 0 {
+			line = line[:i]
+		}
+	}
+	line = bytes.TrimRight(line, " \t")
+	return start, start + len(bytes.TrimSuffix(line, []byte(":")))
+}
+
+// offset converts a 1-based line and rune column to a byte offset.
+func (y *yamlSource) offset(line int, column int) int {
+	at := y.lines[line-1]
+	for i := 1; i < column && at < len(y.src); i++ {
+		_, size := utf8.DecodeRune(y.src[at:])
+		at += size
+	}
+	return at
+}
+
+// lineEnd returns the offset just past line's newline.
+func (y *yamlSource) lineEnd(line int) int {
+	if line < len(y.lines) {
+		return y.lines[line]
+	}
+	return len(y.src)
+}
+
+func (y *yamlSource) lineText(line int) string {
+	return strings.TrimRight(string(y.src[y.lines[line-1]:y.lineEnd(line)]), "\r\n")
+}
+
+func (y *yamlSource) blank(line int) bool {
+	return line <= len(y.lines) && strings.TrimSpace(y.lineText(line)) == ""
+}
+
+// nestedCatalogLines renders entries below the mapping keys, with each level
+// indented by indent spaces.
+func nestedCatalogLines(keys []string, entries map[string]string, packages []string, indent int) []string {
+	lines := []string{}
+	for depth, key := range keys {
+		lines = append(lines, strings.Repeat(" ", depth*indent)+yamlScalar(key, 0)+":")
+	}
+	prefix := strings.Repeat(" ", len(keys)*indent)
+	for _, pkg := range packages {
+		lines = append(lines, prefix+yamlScalar(pkg, 0)+": "+yamlScalar(entries[pkg], 0))
+	}
+	return lines
+}
+
+// yamlScalar renders value as a string scalar, keeping a quoted style and
+// quoting plain values that would not read back as strings.
+func yamlScalar(value string, style yaml.Style) string {
+	node := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value, Style: style & (yaml.DoubleQuotedStyle | yaml.SingleQuotedStyle)}
+	out, err := yaml.Marshal(node)
+	if err != nil {
+		return value
+	}
+	return strings.TrimSuffix(string(out), "\n")
+}
+
+// detectYAMLIndent returns the smallest indentation used in content, or 2.
+func detectYAMLIndent(content []byte) int {
+	indent := 0
+	for _, line := range strings.Split(string(content), "\n") {
+		trimmed := strings.TrimLeft(line, " ")
+		if strings.TrimSpace(trimmed) == "" || strings.HasPrefix(trimmed, "#") || len(trimmed) == len(line) {
+			continue
+		}
+		if width := len(line) - len(trimmed); indent == 0 || width < indent {
+			indent = width
+		}
+	}
+	if indent < 2 {
+		return 2
+	}
+	return indent
+}
+
+func markAliasTargets(node *yaml.Node, targets map[*yaml.Node]bool) {
+	if node.Kind == yaml.AliasNode && node.Alias != nil {
+		targets[node.Alias] = true
+	}
+	for _, child := range node.Content {
+		markAliasTargets(child, targets)
+	}
+}
+
+func containsAliasTarget(node *yaml.Node, targets map[*yaml.Node]bool) bool {
+	if targets[node] {
+		return true
+	}
+	for _, child := range node.Content {
+		if containsAliasTarget(child, targets) {
+			return true
+		}
+	}
+	return false
+}
+
+// mappingEntry returns the key and raw value nodes for key.
+func mappingEntry(mapping *yaml.Node, key string) (*yaml.Node, *yaml.Node) {
+	if mapping == nil || mapping.Kind != yaml.MappingNode {
+		return nil, nil
+	}
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return mapping.Content[i], mapping.Content[i+1]
+		}
+	}
+	return nil, nil
+}
+
+func mappingValue(mapping *yaml.Node, key string) *yaml.Node {
+	_, value := mappingEntry(mapping, key)
+	return resolveAlias(value)
+}
+
+func lookupMapping(mapping *yaml.Node, keys ...string) *yaml.Node {
+	current := mapping
+	for _, key := range keys {
+		current = mappingValue(current, key)
+		if current == nil || current.Kind != yaml.MappingNode {
+			return nil
+		}
+	}
+	return current
+}
+
+func resolveAlias(node *yaml.Node) *yaml.Node {
+	for node != nil && node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+	return node
+}
@@ -0,0 +1,57 @@
+package execadapter
+
+import (
+	"bytes"
+	"io"
+	"sync"
+)
+
+// outputMu serializes line writes from concurrently running commands.
+var outputMu sync.Mutex
+
+// prefixWriter buffers partial lines and writes each complete line with a prefix.
+type prefixWriter struct {
+	out    io.Writer
+	prefix string
+	buf    []byte
+}
+
+func newPrefixWriter(out io.Writer, prefix string) *prefixWriter {
+	return &prefixWriter{out: out, prefix: prefix}
+}
+
+func (w *prefixWriter) Write(p []byte) (int, error) {
+	w.buf = append(w.buf, p...)
+	for {
+		idx := bytes.IndexByte(w.buf, '\n')
+		if idx < 0 {
+			break
+		}
+		if err := w.writeLine(w.buf[:idx+1]); err != nil {
+			return 0, err
+		}
+		w.buf = w.buf[idx+1:]
+	}
+	return len(p), nil
+}
+
+// Flush writes any trailing partial line, terminating it with a newline.
+func (w *prefixWriter) Flush() error {
+	if len(w.buf) == 0 {
+		return nil
+	}
+	line := append(w.buf, '\n')
+	w.buf = nil
+	return w.writeLine(line)
+}
+
+func (w *prefixWriter) writeLine(line []byte) error {
+	outputMu.Lock()
+	defer outputMu.Unlock()
+
+	if _, err := io.WriteString(w.out, w.prefix); err != nil {
+		return err
+	}
+	_, err := w.out.Write(line)
+	return err
+}
@@ -12,14 +12,23 @@ import (
 	"strings"
 
 	"ordo/internal/domain"
+	"ordo/internal/ports"
 )
 
-type Runner struct{}
+type Runner struct {
+	stdout func() *os.File
+}
 
 func NewRunner() Runner {
 	return Runner{}
 }
 
+// NewRunnerWithStdout sends command stdout to the file returned by stdout,
+// looked up each time a command starts.
+func NewRunnerWithStdout(stdout func() *os.File) Runner {
+	return Runner{stdout: stdout}
+}
+
 func (r Runner) Run(ctx context.Context, dir string, argv []string) error {
 	if len(argv) == 0 {
 		return fmt.Errorf("empty command")
@@ -27,7 +36,7 @@ func (r Runner) Run(ctx context.Context, dir string, argv []string) error {
 
 	cmd := exec.CommandContext(ctx, argv[0], argv[1:]...)
 	cmd.Dir = dir
-	cmd.Stdout = os.Stdout
+	cmd.Stdout = r.stdoutFile()
 	cmd.Stderr = os.Stderr
 	cmd.Stdin = os.Stdin
 
@@ -37,6 +46,32 @@ func (r Runner) Run(ctx context.Context, dir string, argv []string) error {
 	return nil
 }
 
+func (r Runner) RunPrefixed(ctx context.Context, dir string, argv []string, prefix ports.StreamPrefix) error {
+	if len(argv) == 0 {
+		return fmt.Errorf("empty command")
+	}
+
+	stdout := newPrefixWriter(r.stdoutFile(), prefix.Stdout)
+	stderr := newPrefixWriter(os.Stderr, prefix.Stderr)
+
+	cmd := exec.CommandContext(ctx, argv[0], argv[1:]...)
+	cmd.Dir = dir
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+
+	err := cmd.Run()
+	_ = stdout.Flush()
+	_ = stderr.Flush()
+	return err
+}
+
+func (r Runner) stdoutFile() *os.File {
+	if r.stdout == nil {
+		return os.Stdout
+	}
+	return r.stdout()
+}
+
 func (r Runner) AvailablePackageManagers(_ context.Context) ([]string, error) {
 	found := make([]string, 0, len(domain.SupportedPackageManagers()))
 	for _, manager := range domain.SupportedPackageManagers() {
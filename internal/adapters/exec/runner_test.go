@@ -1,6 +1,7 @@
 package execadapter
 
 import (
+	"bytes"
 	"context"
 	"os"
 	"path/filepath"
@@ -135,3 +136,24 @@ func writeExecutable(t *testing.T, dir string, name string) {
 		t.Fatalf("write executable %q: %v", filename, err)
 	}
 }
+
+func TestPrefixWriterPrefixesCompleteLines(t *testing.T) {
+	var buf bytes.Buffer
+	w := newPrefixWriter(&buf, "ui | ")
+
+	if _, err := w.Write([]byte("first\nsec")); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	if _, err := w.Write([]byte("ond\nthird")); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	if got := buf.String(); got != "ui | first\nui | second\n" {
+		t.Fatalf("output before flush = %q", got)
+	}
+	if err := w.Flush(); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+	if got := buf.String(); got != "ui | first\nui | second\nui | third\n" {
+		t.Fatalf("output after flush = %q", got)
+	}
+}
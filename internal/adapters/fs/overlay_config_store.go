@@ -0,0 +1,89 @@
+package fs
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"ordo/internal/ports"
+)
+
+// OverlayConfigStore passes through to a base store until Enable is called.
+// After that, writes are kept in memory and handed to a recorder, and reads see
+// the in-memory content, so a dry run behaves like a real one without touching
+// disk.
+type OverlayConfigStore struct {
+	base ports.ConfigStore
+
+	mu       sync.Mutex
+	enabled  bool
+	recorder ports.ChangeRecorder
+	files    map[string][]byte
+}
+
+func NewOverlayConfigStore(base ports.ConfigStore) *OverlayConfigStore {
+	return &OverlayConfigStore{base: base, files: map[string][]byte{}}
+}
+
+// Enable stops writes from reaching the base store and reports each one to
+// recorder instead.
+func (c *OverlayConfigStore) Enable(recorder ports.ChangeRecorder) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.enabled = true
+	c.recorder = recorder
+}
+
+func (c *OverlayConfigStore) MkdirAll(path string, perm os.FileMode) error {
+	c.mu.Lock()
+	enabled := c.enabled
+	c.mu.Unlock()
+	if enabled {
+		return nil
+	}
+	return c.base.MkdirAll(path, perm)
+}
+
+func (c *OverlayConfigStore) Exists(path string) (bool, error) {
+	c.mu.Lock()
+	_, ok := c.files[filepath.Clean(path)]
+	c.mu.Unlock()
+	if ok {
+		return true, nil
+	}
+	return c.base.Exists(path)
+}
+
+func (c *OverlayConfigStore) ReadFile(path string) ([]byte, error) {
+	c.mu.Lock()
+	content, ok := c.files[filepath.Clean(path)]
+	c.mu.Unlock()
+	if ok {
+		return append([]byte(nil), content...), nil
+	}
+	return c.base.ReadFile(path)
+}
+
+func (c *OverlayConfigStore) WriteFile(path string, data []byte, perm os.FileMode) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.enabled {
+		return c.base.WriteFile(path, data, perm)
+	}
+
+	key := filepath.Clean(path)
+	before, ok := c.files[key]
+	if !ok {
+		var err error
+		before, err = c.base.ReadFile(path)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	}
+	c.files[key] = append([]byte(nil), data...)
+	if c.recorder != nil {
+		c.recorder.RecordFile(key, before, data)
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package fs
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+type recordedFile struct {
+	path   string
+	before string
+	after  string
+}
+
+type fakeChangeRecorder struct {
+	files []recordedFile
+}
+
+func (r *fakeChangeRecorder) RecordFile(path string, before []byte, after []byte) {
+	r.files = append(r.files, recordedFile{path: path, before: string(before), after: string(after)})
+}
+
+func TestOverlayConfigStoreKeepsWritesOffDiskWhenEnabled(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "package.json")
+	if err := os.WriteFile(path, []byte("old\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	recorder := &fakeChangeRecorder{}
+	store := NewOverlayConfigStore(NewConfigStore())
+	store.Enable(recorder)
+
+	if err := store.WriteFile(path, []byte("new\n"), 0o644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	if err := store.WriteFile(filepath.Join(dir, "created.yaml"), []byte("x: 1\n"), 0o644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	onDisk, err := os.ReadFile(path)
+	if err != nil || string(onDisk) != "old\n" {
+		t.Fatalf("disk content = %q, %v; want untouched", onDisk, err)
+	}
+	overlaid, err := store.ReadFile(path)
+	if err != nil || string(overlaid) != "new\n" {
+		t.Fatalf("ReadFile() = %q, %v; want overlay content", overlaid, err)
+	}
+	if exists, _ := store.Exists(filepath.Join(dir, "created.yaml")); !exists {
+		t.Fatal("Exists() = false for a file written in the overlay")
+	}
+	if _, err := os.Stat(filepath.Join(dir, "created.yaml")); !os.IsNotExist(err) {
+		t.Fatalf("created.yaml reached disk: %v", err)
+	}
+
+	if len(recorder.files) != 2 {
+		t.Fatalf("recorded %d files, want 2", len(recorder.files))
+	}
+	if got := recorder.files[0]; got.before != "old\n" || got.after != "new\n" {
+		t.Fatalf("first change = %+v", got)
+	}
+	if got := recorder.files[1]; got.before != "" || got.after != "x: 1\n" {
+		t.Fatalf("second change = %+v", got)
+	}
+}
+
+func TestOverlayConfigStorePassesThroughUntilEnabled(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ordo.json")
+	store := NewOverlayConfigStore(NewConfigStore())
+
+	if err := store.WriteFile(path, []byte("{}\n"), 0o644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	if content, err := os.ReadFile(path); err != nil || string(content) != "{}\n" {
+		t.Fatalf("disk content = %q, %v", content, err)
+	}
+}
@@ -0,0 +1,51 @@
+package fs
+
+import (
+	"path/filepath"
+
+	"ordo/internal/domain"
+)
+
+// FindProjectRoot walks upward from start to the nearest directory that looks like
+// a monorepo root: one holding a lockfile, pnpm-workspace.yaml, or a package.json
+// that declares workspaces. It returns start when no such directory exists.
+func FindProjectRoot(start string) (string, error) {
+	abs, err := filepath.Abs(start)
+	if err != nil {
+		return "", err
+	}
+
+	dir := abs
+	for {
+		found, err := isProjectRoot(dir)
+		if err != nil {
+			return "", err
+		}
+		if found {
+			return dir, nil
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return abs, nil
+		}
+		dir = parent
+	}
+}
+
+func isProjectRoot(dir string) (bool, error) {
+	for _, lockfile := range domain.Lockfiles() {
+		if fileExists(filepath.Join(dir, lockfile)) {
+			return true, nil
+		}
+	}
+	if fileExists(filepath.Join(dir, "pnpm-workspace.yaml")) {
+		return true, nil
+	}
+
+	workspaces, err := readPackageJSONWorkspaces(filepath.Join(dir, "package.json"))
+	if err != nil {
+		return false, err
+	}
+	return len(workspaces) > 0, nil
+}
@@ -0,0 +1,53 @@
+package fs
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestFindProjectRoot(t *testing.T) {
+	root := t.TempDir()
+	writeFixture(t, root, map[string]string{
+		"package.json":              `{"workspaces":["packages/*"]}`,
+		"packages/web/package.json": `{"name":"web"}`,
+		"packages/web/src/main.ts":  "",
+	})
+
+	got, err := FindProjectRoot(filepath.Join(root, "packages/web/src"))
+	if err != nil {
+		t.Fatalf("FindProjectRoot() error = %v", err)
+	}
+	if got != root {
+		t.Fatalf("FindProjectRoot() = %q, want %q", got, root)
+	}
+}
+
+func TestFindProjectRootByLockfile(t *testing.T) {
+	root := t.TempDir()
+	writeFixture(t, root, map[string]string{
+		"pnpm-lock.yaml":            "",
+		"package.json":              `{}`,
+		"packages/web/package.json": `{"name":"web"}`,
+	})
+
+	got, err := FindProjectRoot(filepath.Join(root, "packages/web"))
+	if err != nil {
+		t.Fatalf("FindProjectRoot() error = %v", err)
+	}
+	if got != root {
+		t.Fatalf("FindProjectRoot() = %q, want %q", got, root)
+	}
+}
+
+func TestFindProjectRootFallsBackToStart(t *testing.T) {
+	start := filepath.Join(t.TempDir(), "app")
+	writeFixture(t, start, map[string]string{"package.json": `{}`})
+
+	got, err := FindProjectRoot(start)
+	if err != nil {
+		t.Fatalf("FindProjectRoot() error = %v", err)
+	}
+	if got != start {
+		t.Fatalf("FindProjectRoot() = %q, want %q", got, start)
+	}
+}
@@ -0,0 +1,55 @@
+package fs
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+
+	"ordo/internal/ports"
+)
+
+// RootedConfigStore resolves relative paths against a project root before
+// passing them to a base store. Absolute paths pass through unchanged, and
+// until SetRoot is called relative paths do too.
+type RootedConfigStore struct {
+	base ports.ConfigStore
+
+	mu   sync.Mutex
+	root string
+}
+
+func NewRootedConfigStore(base ports.ConfigStore) *RootedConfigStore {
+	return &RootedConfigStore{base: base}
+}
+
+// SetRoot makes relative paths resolve against root.
+func (c *RootedConfigStore) SetRoot(root string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.root = root
+}
+
+func (c *RootedConfigStore) MkdirAll(path string, perm os.FileMode) error {
+	return c.base.MkdirAll(c.resolve(path), perm)
+}
+
+func (c *RootedConfigStore) Exists(path string) (bool, error) {
+	return c.base.Exists(c.resolve(path))
+}
+
+func (c *RootedConfigStore) ReadFile(path string) ([]byte, error) {
+	return c.base.ReadFile(c.resolve(path))
+}
+
+func (c *RootedConfigStore) WriteFile(path string, data []byte, perm os.FileMode) error {
+	return c.base.WriteFile(c.resolve(path), data, perm)
+}
+
+func (c *RootedConfigStore) resolve(path string) string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.root == "" || filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(c.root, path)
+}
@@ -0,0 +1,35 @@
+package fs
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRootedConfigStoreResolvesRelativePaths(t *testing.T) {
+	root := t.TempDir()
+	other := t.TempDir()
+	store := NewRootedConfigStore(NewConfigStore())
+	store.SetRoot(root)
+
+	if err := store.MkdirAll("packages/ui", 0o755); err != nil {
+		t.Fatalf("MkdirAll() error = %v", err)
+	}
+	if err := store.WriteFile("packages/ui/package.json", []byte("{}\n"), 0o644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(root, "packages/ui/package.json")); err != nil {
+		t.Fatalf("relative write did not land under the root: %v", err)
+	}
+	if ok, err := store.Exists("packages/ui/package.json"); err != nil || !ok {
+		t.Fatalf("Exists() = %v, %v, want true", ok, err)
+	}
+
+	absolute := filepath.Join(other, "ordo.json")
+	if err := store.WriteFile(absolute, []byte("{}\n"), 0o644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	if content, err := store.ReadFile(absolute); err != nil || string(content) != "{}\n" {
+		t.Fatalf("ReadFile(%s) = %q, %v", absolute, content, err)
+	}
+}
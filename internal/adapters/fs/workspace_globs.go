@@ -0,0 +1,218 @@
+package fs
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// workspacePatterns holds the workspace globs declared by the root package.json
+// and pnpm-workspace.yaml. Patterns prefixed with "!" exclude matches.
+type workspacePatterns struct {
+	include []string
+	exclude []string
+}
+
+// declared reports whether any workspace globs were given. A list of only
+// negations still counts: it selects no workspaces rather than the whole tree.
+func (p workspacePatterns) declared() bool {
+	return len(p.include) > 0 || len(p.exclude) > 0
+}
+
+func (p workspacePatterns) matches(relDir string) bool {
+	if relDir == "." {
+		return true
+	}
+	included := false
+	for _, pattern := range p.include {
+		if matchWorkspaceGlob(pattern, relDir) {
+			included = true
+			break
+		}
+	}
+	if !included {
+		return false
+	}
+	for _, pattern := range p.exclude {
+		if matchWorkspaceGlob(pattern, relDir) {
+			return false
+		}
+	}
+	return true
+}
+
+// workspaceBase is a directory to walk for a declared glob: the glob's literal
+// leading segments, and how many levels below them it can match (-1 for "**").
+type workspaceBase struct {
+	dir   string
+	depth int
+}
+
+// bases returns one walk start per include pattern, dropping any base already
+// covered by another with unlimited depth.
+func (p workspacePatterns) bases() []workspaceBase {
+	bases := make([]workspaceBase, 0, len(p.include))
+	for _, pattern := range p.include {
+		segments := strings.Split(pattern, "/")
+		literal := 0
+		for literal < len(segments) && !strings.ContainsAny(segments[literal], "*?[") {
+			literal++
+		}
+		base := workspaceBase{dir: ".", depth: len(segments) - literal}
+		if literal > 0 {
+			base.dir = strings.Join(segments[:literal], "/")
+		}
+		for _, segment := range segments[literal:] {
+			if segment == "**" {
+				base.depth = -1
+				break
+			}
+		}
+		bases = append(bases, base)
+	}
+
+	kept := make([]workspaceBase, 0, len(bases))
+	for i, base := range bases {
+		covered := false
+		for j, other := range bases {
+			if i == j || other.depth >= 0 || (other == base && j > i) {
+				continue
+			}
+			if other.dir == base.dir || withinDir(other.dir, base.dir) {
+				covered = true
+				break
+			}
+		}
+		if !covered {
+			kept = append(kept, base)
+		}
+	}
+	return kept
+}
+
+func withinDir(parent string, dir string) bool {
+	return parent == "." || strings.HasPrefix(dir, parent+"/")
+}
+
+func readWorkspacePatterns(root string) (workspacePatterns, error) {
+	var patterns workspacePatterns
+
+	fromPackageJSON, err := readPackageJSONWorkspaces(filepath.Join(root, "package.json"))
+	if err != nil {
+		return workspacePatterns{}, err
+	}
+	fromPNPM, err := readPNPMWorkspacePackages(filepath.Join(root, "pnpm-workspace.yaml"))
+	if err != nil {
+		return workspacePatterns{}, err
+	}
+
+	for _, raw := range append(fromPackageJSON, fromPNPM...) {
+		pattern := strings.TrimSpace(raw)
+		negated := strings.HasPrefix(pattern, "!")
+		pattern = normalizeWorkspacePattern(strings.TrimPrefix(pattern, "!"))
+		if pattern == "" {
+			continue
+		}
+		if negated {
+			patterns.exclude = append(patterns.exclude, pattern)
+			continue
+		}
+		patterns.include = append(patterns.include, pattern)
+	}
+	return patterns, nil
+}
+
+// readPackageJSONWorkspaces supports both the array form and the object form
+// ({"packages": [...]}) of the workspaces field.
+func readPackageJSONWorkspaces(path string) ([]string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var manifest struct {
+		Workspaces json.RawMessage `json:"workspaces"`
+	}
+	if err := json.Unmarshal(content, &manifest); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+	if len(manifest.Workspaces) == 0 || string(manifest.Workspaces) == "null" {
+		return nil, nil
+	}
+
+	var list []string
+	if err := json.Unmarshal(manifest.Workspaces, &list); err == nil {
+		return list, nil
+	}
+	var object struct {
+		Packages []string `json:"packages"`
+	}
+	if err := json.Unmarshal(manifest.Workspaces, &object); err != nil {
+		return nil, fmt.Errorf("parse %s: workspaces must be an array or an object with packages", path)
+	}
+	return object.Packages, nil
+}
+
+func readPNPMWorkspacePackages(path string) ([]string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var manifest struct {
+		Packages []string `yaml:"packages"`
+	}
+	if err := yaml.Unmarshal(content, &manifest); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+	return manifest.Packages, nil
+}
+
+func normalizeWorkspacePattern(pattern string) string {
+	pattern = filepath.ToSlash(strings.TrimSpace(pattern))
+	pattern = strings.TrimPrefix(pattern, "./")
+	pattern = strings.TrimSuffix(pattern, "/package.json")
+	return strings.TrimSuffix(pattern, "/")
+}
+
+// matchWorkspaceGlob matches a slash-separated directory against a glob where "**"
+// spans any number of path segments and other segments follow path.Match.
+func matchWorkspaceGlob(pattern string, dir string) bool {
+	return matchSegments(strings.Split(pattern, "/"), strings.Split(dir, "/"))
+}
+
+func matchSegments(pattern []string, dir []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			rest := pattern[1:]
+			for i := 0; i <= len(dir); i++ {
+				if matchSegments(rest, dir[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(dir) == 0 {
+			return false
+		}
+		ok, err := path.Match(pattern[0], dir[0])
+		if err != nil || !ok {
+			return false
+		}
+		pattern = pattern[1:]
+		dir = dir[1:]
+	}
+	return len(dir) == 0
+}
@@ -6,10 +6,14 @@ import (
 	"io/fs"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 
 	"ordo/internal/domain"
 )
 
+// ignoredDirs are skipped when no workspace globs are declared and the whole
+// tree is walked for package.json files.
 var ignoredDirs = map[string]struct{}{
 	".git":         {},
 	"node_modules": {},
@@ -19,6 +23,12 @@ var ignoredDirs = map[string]struct{}{
 	".next":        {},
 }
 
+// installDirs never hold workspaces, even under a declared glob.
+var installDirs = map[string]struct{}{
+	".git":         {},
+	"node_modules": {},
+}
+
 type WorkspaceIndexer struct {
 	root string
 }
@@ -27,10 +37,63 @@ func NewWorkspaceIndexer(root string) WorkspaceIndexer {
 	return WorkspaceIndexer{root: root}
 }
 
+// Discover indexes the root package and its workspaces. When the root declares
+// workspace globs (package.json "workspaces" or pnpm-workspace.yaml "packages"),
+// only the base directories of those globs are walked and only matching
+// directories are indexed; otherwise every package.json in the tree counts.
 func (w WorkspaceIndexer) Discover(ctx context.Context) ([]domain.PackageInfo, error) {
+	patterns, err := readWorkspacePatterns(w.root)
+	if err != nil {
+		return nil, err
+	}
+
+	if !patterns.declared() {
+		infos := make([]domain.PackageInfo, 0)
+		err := w.walk(ctx, workspaceBase{dir: ".", depth: -1}, ignoredDirs, func(relDir string) error {
+			return w.index(relDir, &infos)
+		})
+		if err != nil {
+			return nil, err
+		}
+		return infos, nil
+	}
+
 	infos := make([]domain.PackageInfo, 0)
+	if fileExists(filepath.Join(w.root, "package.json")) {
+		if err := w.index(".", &infos); err != nil {
+			return nil, err
+		}
+	}
+
+	seen := map[string]struct{}{".": {}}
+	for _, base := range patterns.bases() {
+		err := w.walk(ctx, base, installDirs, func(relDir string) error {
+			if _, ok := seen[relDir]; ok || !patterns.matches(relDir) {
+				return nil
+			}
+			seen[relDir] = struct{}{}
+			return w.index(relDir, &infos)
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	sort.SliceStable(infos, func(i, j int) bool {
+		return infos[i].Dir == "." || (infos[j].Dir != "." && infos[i].Dir < infos[j].Dir)
+	})
+	return infos, nil
+}
+
+// walk calls found with the slash-separated directory of every package.json
+// under base, at most base.depth levels below it when depth is not negative.
+func (w WorkspaceIndexer) walk(ctx context.Context, base workspaceBase, skipDirs map[string]struct{}, found func(relDir string) error) error {
+	start := filepath.Join(w.root, filepath.FromSlash(base.dir))
+	if info, err := os.Stat(start); err != nil || !info.IsDir() {
+		return nil
+	}
 
-	err := filepath.WalkDir(w.root, func(path string, d fs.DirEntry, err error) error {
+	return filepath.WalkDir(start, func(path string, d fs.DirEntry, err error) error {
 		if err != nil {
 			return err
 		}
@@ -41,9 +104,21 @@ func (w WorkspaceIndexer) Discover(ctx context.Context) ([]domain.PackageInfo, e
 		}
 
 		if d.IsDir() {
-			if _, skip := ignoredDirs[d.Name()]; skip {
+			if path == start {
+				return nil
+			}
+			if _, skip := skipDirs[d.Name()]; skip {
 				return filepath.SkipDir
 			}
+			if base.depth >= 0 {
+				rel, err := filepath.Rel(start, path)
+				if err != nil {
+					return err
+				}
+				if len(strings.Split(filepath.ToSlash(rel), "/")) > base.depth {
+					return filepath.SkipDir
+				}
+			}
 			return nil
 		}
 
@@ -51,8 +126,7 @@ func (w WorkspaceIndexer) Discover(ctx context.Context) ([]domain.PackageInfo, e
 			return nil
 		}
 
-		dir := filepath.Dir(path)
-		relDir, err := filepath.Rel(w.root, dir)
+		relDir, err := filepath.Rel(w.root, filepath.Dir(path))
 		if err != nil {
 			return err
 		}
@@ -60,38 +134,38 @@ func (w WorkspaceIndexer) Discover(ctx context.Context) ([]domain.PackageInfo, e
 		if relDir == "" {
 			relDir = "."
 		}
+		return found(relDir)
+	})
+}
 
-		content, err := os.ReadFile(path)
-		if err != nil {
-			return err
-		}
+func (w WorkspaceIndexer) index(relDir string, infos *[]domain.PackageInfo) error {
+	content, err := os.ReadFile(filepath.Join(w.root, filepath.FromSlash(relDir), "package.json"))
+	if err != nil {
+		return err
+	}
 
-		pkg, err := parsePackageInfo(content)
-		if err != nil {
-			return err
-		}
-		pkg.Dir = relDir
-		pkg.Lockfiles = map[string]bool{}
+	pkg, err := parsePackageInfo(content)
+	if err != nil {
+		return err
+	}
+	pkg.Dir = relDir
+	pkg.Lockfiles = map[string]bool{}
 
-		if relDir == "." {
-			for _, lockfile := range []string{"bun.lockb", "bun.lock", "pnpm-lock.yaml", "yarn.lock", "package-lock.json", "npm-shrinkwrap.json"} {
-				if fileExists(filepath.Join(w.root, lockfile)) {
-					pkg.Lockfiles[lockfile] = true
-				}
+	if relDir == "." {
+		for _, lockfile := range domain.Lockfiles() {
+			if fileExists(filepath.Join(w.root, lockfile)) {
+				pkg.Lockfiles[lockfile] = true
 			}
 		}
-
-		infos = append(infos, pkg)
-		return nil
-	})
-	if err != nil {
-		return nil, err
 	}
 
-	return infos, nil
+	*infos = append(*infos, pkg)
+	return nil
 }
 
 type packageJSON struct {
+	Name                 string            `json:"name"`
+	PackageManager       string            `json:"packageManager"`
 	Scripts              map[string]string `json:"scripts"`
 	Dependencies         map[string]string `json:"dependencies"`
 	DevDependencies      map[string]string `json:"devDependencies"`
@@ -113,9 +187,17 @@ func parsePackageInfo(content []byte) (domain.PackageInfo, error) {
 	}
 
 	return domain.PackageInfo{
+		Name:               manifest.Name,
+		PackageManager:     manifest.PackageManager,
 		Scripts:            scripts,
 		Dependencies:       deps,
 		DependencyVersions: versions,
+		DependencyBuckets: map[domain.PresetBucket]map[string]string{
+			domain.BucketDependencies:         nonNilStringMap(manifest.Dependencies),
+			domain.BucketDevDependencies:      nonNilStringMap(manifest.DevDependencies),
+			domain.BucketPeerDependencies:     nonNilStringMap(manifest.PeerDependencies),
+			domain.BucketOptionalDependencies: nonNilStringMap(manifest.OptionalDependencies),
+		},
 	}, nil
 }
 
@@ -142,6 +224,13 @@ func dependencyData(manifest packageJSON) (map[string]struct{}, map[string]strin
 	return deps, versions
 }
 
+func nonNilStringMap(items map[string]string) map[string]string {
+	if items == nil {
+		return map[string]string{}
+	}
+	return items
+}
+
 func fileExists(path string) bool {
 	info, err := os.Stat(path)
 	if err != nil {
@@ -0,0 +1,183 @@
+package fs
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"ordo/internal/domain"
+)
+
+func writeFixture(t *testing.T, root string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("MkdirAll() error = %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatalf("WriteFile() error = %v", err)
+		}
+	}
+}
+
+func discoveredDirs(t *testing.T, root string) []string {
+	t.Helper()
+	infos, err := NewWorkspaceIndexer(root).Discover(context.Background())
+	if err != nil {
+		t.Fatalf("Discover() error = %v", err)
+	}
+	dirs := make([]string, 0, len(infos))
+	for _, info := range infos {
+		dirs = append(dirs, info.Dir)
+	}
+	sort.Strings(dirs)
+	return dirs
+}
+
+func assertDirs(t *testing.T, got []string, want []string) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("dirs = %#v, want %#v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("dirs = %#v, want %#v", got, want)
+		}
+	}
+}
+
+func TestDiscoverHonorsPackageJSONWorkspaces(t *testing.T) {
+	root := t.TempDir()
+	writeFixture(t, root, map[string]string{
+		"package.json":                        `{"name":"root","workspaces":["packages/*","./apps/web"]}`,
+		"packages/ui/package.json":            `{"name":"@acme/ui"}`,
+		"packages/ui/fixtures/a/package.json": `{"name":"fixture"}`,
+		"apps/web/package.json":               `{"name":"@acme/web"}`,
+		"examples/demo/package.json":          `{"name":"demo"}`,
+	})
+
+	assertDirs(t, discoveredDirs(t, root), []string{".", "apps/web", "packages/ui"})
+}
+
+func TestDiscoverHonorsWorkspacesObjectForm(t *testing.T) {
+	root := t.TempDir()
+	writeFixture(t, root, map[string]string{
+		"package.json":              `{"workspaces":{"packages":["packages/**"]}}`,
+		"packages/ui/package.json":  `{}`,
+		"packages/a/b/package.json": `{}`,
+		"tools/cli/package.json":    `{}`,
+	})
+
+	assertDirs(t, discoveredDirs(t, root), []string{".", "packages/a/b", "packages/ui"})
+}
+
+func TestDiscoverHonorsPNPMWorkspaceNegations(t *testing.T) {
+	root := t.TempDir()
+	writeFixture(t, root, map[string]string{
+		"package.json":                 "{}",
+		"pnpm-workspace.yaml":          "packages:\n  - 'packages/*'\n  - '!packages/legacy'\n",
+		"packages/ui/package.json":     `{}`,
+		"packages/legacy/package.json": `{}`,
+	})
+
+	assertDirs(t, discoveredDirs(t, root), []string{".", "packages/ui"})
+}
+
+func TestDiscoverTreatsNegationOnlyWorkspacesAsDeclared(t *testing.T) {
+	root := t.TempDir()
+	writeFixture(t, root, map[string]string{
+		"package.json":                 "{}",
+		"pnpm-workspace.yaml":          "packages:\n  - '!packages/legacy'\n",
+		"packages/ui/package.json":     `{}`,
+		"packages/legacy/package.json": `{}`,
+	})
+
+	assertDirs(t, discoveredDirs(t, root), []string{"."})
+}
+
+func TestDiscoverWalksTreeWithoutDeclaredWorkspaces(t *testing.T) {
+	root := t.TempDir()
+	writeFixture(t, root, map[string]string{
+		"package.json":                    "{}",
+		"packages/ui/package.json":        `{}`,
+		"examples/demo/package.json":      `{}`,
+		"node_modules/react/package.json": `{}`,
+	})
+
+	assertDirs(t, discoveredDirs(t, root), []string{".", "examples/demo", "packages/ui"})
+}
+
+func TestMatchWorkspaceGlob(t *testing.T) {
+	tests := []struct {
+		pattern string
+		dir     string
+		want    bool
+	}{
+		{pattern: "packages/*", dir: "packages/ui", want: true},
+		{pattern: "packages/*", dir: "packages/ui/nested", want: false},
+		{pattern: "packages/**", dir: "packages/ui/nested", want: true},
+		{pattern: "**/web", dir: "apps/web", want: true},
+		{pattern: "apps/web", dir: "apps/docs", want: false},
+	}
+
+	for _, tc := range tests {
+		if got := matchWorkspaceGlob(tc.pattern, tc.dir); got != tc.want {
+			t.Fatalf("matchWorkspaceGlob(%q, %q) = %v, want %v", tc.pattern, tc.dir, got, tc.want)
+		}
+	}
+}
+
+func TestDiscoverKeepsDeclaredWorkspacesInIgnoredDirs(t *testing.T) {
+	root := t.TempDir()
+	writeFixture(t, root, map[string]string{
+		"package.json":                            `{"workspaces":["build/*","packages/*"]}`,
+		"build/tools/package.json":                `{}`,
+		"packages/ui/package.json":                `{}`,
+		"packages/ui/node_modules/x/package.json": `{}`,
+		"packages/ui/dist/package.json":           `{}`,
+	})
+
+	assertDirs(t, discoveredDirs(t, root), []string{".", "build/tools", "packages/ui"})
+}
+
+func TestWorkspacePatternBases(t *testing.T) {
+	patterns := workspacePatterns{include: []string{"packages/*", "apps/web", "packages/**", "tools/*/pkg"}}
+
+	got := patterns.bases()
+	want := []workspaceBase{
+		{dir: "apps/web", depth: 0},
+		{dir: "packages", depth: -1},
+		{dir: "tools", depth: 2},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("bases() = %#v, want %#v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("bases() = %#v, want %#v", got, want)
+		}
+	}
+}
+
+func TestParsePackageInfoKeepsDependencyBuckets(t *testing.T) {
+	pkg, err := parsePackageInfo([]byte(`{
+  "dependencies": {"react": "catalog:"},
+  "devDependencies": {"react": "^19.0.0", "vitest": "^2.0.0"},
+  "peerDependencies": {"react-dom": "^19.0.0"}
+}`))
+	if err != nil {
+		t.Fatalf("parsePackageInfo() error = %v", err)
+	}
+	if pkg.DependencyVersions["react"] != "catalog:" {
+		t.Fatalf("DependencyVersions = %#v", pkg.DependencyVersions)
+	}
+	if got := pkg.DependencyBuckets[domain.BucketDevDependencies]; got["react"] != "^19.0.0" || got["vitest"] != "^2.0.0" {
+		t.Fatalf("devDependencies = %#v", got)
+	}
+	if got := pkg.DependencyBuckets[domain.BucketOptionalDependencies]; got == nil || len(got) != 0 {
+		t.Fatalf("optionalDependencies = %#v, want empty map", got)
+	}
+}
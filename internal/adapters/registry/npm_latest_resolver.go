@@ -6,56 +6,98 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"sort"
 	"strings"
+	"time"
+
+	"ordo/internal/domain"
 )
 
-const defaultNPMPackageURL = "https://registry.npmjs.org"
+const (
+	defaultNPMPackageURL = "https://registry.npmjs.org"
+	// abbreviatedMetadata asks the registry for the install-time packument,
+	// which lists versions and dist-tags without readmes and tarball details.
+	abbreviatedMetadata = "application/vnd.npm.install-v1+json"
+	// defaultMetadataTimeout covers the outdated and upgrade lookups, which
+	// fetch every version of a package rather than one dist-tag.
+	defaultMetadataTimeout = 10 * time.Second
+)
 
 type NPMLatestResolver struct {
-	client   *http.Client
-	endpoint string
+	client         *http.Client
+	metadataClient *http.Client
+	endpoint       string
 }
 
 func NewNPMLatestResolver() *NPMLatestResolver {
 	return &NPMLatestResolver{
-		client:   &http.Client{Timeout: defaultHTTPTimeout},
-		endpoint: defaultNPMPackageURL,
+		client:         &http.Client{Timeout: defaultHTTPTimeout},
+		metadataClient: &http.Client{Timeout: defaultMetadataTimeout},
+		endpoint:       defaultNPMPackageURL,
 	}
 }
 
 func (r *NPMLatestResolver) LatestVersion(ctx context.Context, packageName string) (string, error) {
+	metadata, err := r.fetchMetadata(ctx, r.client, packageName)
+	if err != nil {
+		return "", err
+	}
+
+	latest := strings.TrimSpace(metadata.DistTags["latest"])
+	if latest == "" {
+		return "", fmt.Errorf("npm latest version not found for %s", metadata.Name)
+	}
+	return latest, nil
+}
+
+func (r *NPMLatestResolver) PackageMetadata(ctx context.Context, packageName string) (domain.PackageMetadata, error) {
+	return r.fetchMetadata(ctx, r.metadataClient, packageName)
+}
+
+func (r *NPMLatestResolver) fetchMetadata(ctx context.Context, client *http.Client, packageName string) (domain.PackageMetadata, error) {
 	name := strings.TrimSpace(packageName)
 	if name == "" {
-		return "", fmt.Errorf("package name cannot be empty")
+		return domain.PackageMetadata{}, fmt.Errorf("package name cannot be empty")
 	}
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, r.endpoint+"/"+url.PathEscape(name), nil)
 	if err != nil {
-		return "", err
+		return domain.PackageMetadata{}, err
 	}
+	req.Header.Set("Accept", abbreviatedMetadata)
 
-	resp, err := r.client.Do(req)
+	resp, err := client.Do(req)
 	if err != nil {
-		return "", err
+		return domain.PackageMetadata{}, err
 	}
 	defer func() {
 		_ = resp.Body.Close()
 	}()
 
+	if resp.StatusCode == http.StatusNotFound {
+		return domain.PackageMetadata{}, fmt.Errorf("%w: %s", domain.ErrPackageNotPublished, name)
+	}
 	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("npm package metadata status: %s", resp.Status)
+		return domain.PackageMetadata{}, fmt.Errorf("npm package metadata status for %s: %s", name, resp.Status)
 	}
 
 	var payload struct {
-		DistTags map[string]string `json:"dist-tags"`
+		DistTags map[string]string          `json:"dist-tags"`
+		Versions map[string]json.RawMessage `json:"versions"`
 	}
 	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
-		return "", err
+		return domain.PackageMetadata{}, err
 	}
 
-	latest := strings.TrimSpace(payload.DistTags["latest"])
-	if latest == "" {
-		return "", fmt.Errorf("npm latest version not found for %s", name)
+	versions := make([]string, 0, len(payload.Versions))
+	for version := range payload.Versions {
+		versions = append(versions, version)
 	}
-	return latest, nil
+	sort.Strings(versions)
+
+	distTags := payload.DistTags
+	if distTags == nil {
+		distTags = map[string]string{}
+	}
+	return domain.PackageMetadata{Name: name, DistTags: distTags, Versions: versions}, nil
 }
@@ -0,0 +1,67 @@
+package registry
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"ordo/internal/domain"
+)
+
+func TestNPMLatestResolverPackageMetadata(t *testing.T) {
+	var accept, path string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		accept = r.Header.Get("Accept")
+		path = r.URL.EscapedPath()
+		_, _ = w.Write([]byte(`{"name":"@types/node","dist-tags":{"latest":"22.1.0","next":"23.0.0-rc.1"},"versions":{"22.1.0":{},"20.0.0":{},"23.0.0-rc.1":{}}}`))
+	}))
+	defer srv.Close()
+
+	r := &NPMLatestResolver{client: srv.Client(), metadataClient: srv.Client(), endpoint: srv.URL}
+	metadata, err := r.PackageMetadata(context.Background(), "@types/node")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if accept != abbreviatedMetadata {
+		t.Fatalf("Accept = %q, want %q", accept, abbreviatedMetadata)
+	}
+	if path != "/@types%2Fnode" {
+		t.Fatalf("path = %q", path)
+	}
+	if got := strings.Join(metadata.Versions, ","); got != "20.0.0,22.1.0,23.0.0-rc.1" {
+		t.Fatalf("versions = %q", got)
+	}
+
+	latest, err := r.LatestVersion(context.Background(), "@types/node")
+	if err != nil || latest != "22.1.0" {
+		t.Fatalf("LatestVersion() = %q, %v", latest, err)
+	}
+}
+
+func TestNPMLatestResolverReportsStatus(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.NotFound(w, r)
+	}))
+	defer srv.Close()
+
+	r := &NPMLatestResolver{client: srv.Client(), metadataClient: srv.Client(), endpoint: srv.URL}
+	if _, err := r.PackageMetadata(context.Background(), "missing-pkg"); err == nil || !strings.Contains(err.Error(), "missing-pkg") {
+		t.Fatalf("PackageMetadata() error = %v, want status error naming the package", err)
+	}
+	if _, err := r.PackageMetadata(context.Background(), "missing-pkg"); !errors.Is(err, domain.ErrPackageNotPublished) {
+		t.Fatalf("PackageMetadata() error = %v, want ErrPackageNotPublished", err)
+	}
+}
+
+func TestNewNPMLatestResolverKeepsLatestVersionTimeout(t *testing.T) {
+	r := NewNPMLatestResolver()
+	if r.client.Timeout != defaultHTTPTimeout {
+		t.Fatalf("client timeout = %v, want %v", r.client.Timeout, defaultHTTPTimeout)
+	}
+	if r.metadataClient.Timeout != defaultMetadataTimeout {
+		t.Fatalf("metadata client timeout = %v, want %v", r.metadataClient.Timeout, defaultMetadataTimeout)
+	}
+}
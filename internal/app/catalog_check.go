@@ -0,0 +1,97 @@
+package app
+
+import (
+	"context"
+	"fmt"
+
+	"ordo/internal/domain"
+)
+
+type CatalogViolationKind string
+
+const (
+	// ViolationUnknownCatalog is a catalog:<name> reference to a catalog that
+	// does not exist.
+	ViolationUnknownCatalog CatalogViolationKind = "unknown-catalog"
+	// ViolationMissingEntry is a catalog reference to a package the catalog
+	// does not define.
+	ViolationMissingEntry CatalogViolationKind = "missing-entry"
+	// ViolationHardcodedRange is an explicit semver range for a package that
+	// has a catalog entry. workspace:, file:, link: and npm: alias specs are
+	// not ranges and never count.
+	ViolationHardcodedRange CatalogViolationKind = "hardcoded-range"
+)
+
+// CatalogViolation is one dependency that breaks catalog consistency.
+type CatalogViolation struct {
+	Kind      CatalogViolationKind
+	Workspace string
+	Bucket    domain.PresetBucket
+	Package   string
+	Spec      string
+	// Catalogs names the referenced catalog, or for a hardcoded range every
+	// catalog that defines the package.
+	Catalogs []string
+}
+
+func (u CatalogUseCase) RunCheck(ctx context.Context) ([]CatalogViolation, error) {
+	snapshot, err := u.discovery.Snapshot(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if !domain.SupportsCatalogs(snapshot.Manager) {
+		return nil, fmt.Errorf("%w: %s", ErrCatalogUnsupported, snapshot.Manager)
+	}
+
+	named, err := u.catalogs.NamedCatalogs(ctx, snapshot.Manager)
+	if err != nil {
+		return nil, err
+	}
+	catalogs := map[string]map[string]string{}
+	owners := map[string][]string{}
+	for _, name := range append([]string{""}, named...) {
+		entries, err := u.catalogs.CatalogEntries(ctx, snapshot.Manager, name)
+		if err != nil {
+			return nil, err
+		}
+		catalogs[name] = entries
+		for _, pkg := range sortedPackageNames(entries) {
+			owners[pkg] = append(owners[pkg], name)
+		}
+	}
+
+	violations := []CatalogViolation{}
+	for _, workspace := range append([]domain.PackageInfo{snapshot.Root}, sortedWorkspaceInfos(snapshot.ByWorkspace)...) {
+		for _, raw := range domain.SupportedPresetBuckets() {
+			bucket := domain.PresetBucket(raw)
+			deps := workspace.DependencyBuckets[bucket]
+			for _, pkg := range sortedPackageNames(deps) {
+				violation := CatalogViolation{
+					Workspace: workspaceUsageKey(workspace),
+					Bucket:    bucket,
+					Package:   pkg,
+					Spec:      deps[pkg],
+				}
+				name, isRef := domain.ParseCatalogReference(deps[pkg])
+				switch entries, ok := catalogs[name]; {
+				case isRef && !ok:
+					violation.Kind, violation.Catalogs = ViolationUnknownCatalog, []string{name}
+				case isRef && !hasCatalogEntry(entries, pkg):
+					violation.Kind, violation.Catalogs = ViolationMissingEntry, []string{name}
+				case !isRef && len(owners[pkg]) > 0 && isSemverRange(deps[pkg]):
+					violation.Kind, violation.Catalogs = ViolationHardcodedRange, owners[pkg]
+				default:
+					continue
+				}
+				violations = append(violations, violation)
+			}
+		}
+	}
+	return violations, nil
+}
+
+func isSemverRange(spec string) bool {
+	_, err := domain.ParseRange(spec)
+	return err == nil
+}
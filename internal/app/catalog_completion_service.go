@@ -75,7 +75,7 @@ func (s CatalogCompletionService) WorkspaceDependencyNames(ctx context.Context,
 		return []string{}, nil
 	}
 
-	pkg, ok := snapshot.ByWorkspace[key]
+	pkg, ok := snapshot.Workspace(key)
 	if !ok {
 		return []string{}, nil
 	}
@@ -90,3 +90,20 @@ func (s CatalogCompletionService) WorkspaceDependencyNames(ctx context.Context,
 
 	return filterAndSort(items, prefix), nil
 }
+
+// DriftingDependencyNames returns packages declared with differing ranges
+// across workspaces, the candidates for catalog consolidate.
+func (s CatalogCompletionService) DriftingDependencyNames(ctx context.Context, prefix string) ([]string, error) {
+	snapshot, err := s.discovery.Snapshot(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	workspaces := append([]domain.PackageInfo{snapshot.Root}, sortedWorkspaceInfos(snapshot.ByWorkspace)...)
+	usage, _ := dependencyRangeUsage(workspaces)
+	items, err := driftingPackages(usage, nil)
+	if err != nil {
+		return nil, err
+	}
+	return filterAndSort(items, prefix), nil
+}
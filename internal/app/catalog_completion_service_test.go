@@ -2,6 +2,7 @@ package app
 
 import (
 	"context"
+	"strings"
 	"testing"
 
 	"ordo/internal/domain"
@@ -96,3 +97,17 @@ func TestCatalogCompletionServiceWorkspaceDependencyNamesUnknownWorkspace(t *tes
 		t.Fatalf("expected no items, got %#v", items)
 	}
 }
+
+func TestCatalogCompletionServiceDriftingDependencyNames(t *testing.T) {
+	discovery := NewDiscoveryService(fakeIndexer{infos: consolidateFixture()})
+	install := NewInstallCompletionService(discovery, nil)
+	svc := NewCatalogCompletionService(discovery, install, &fakeCatalogStore{})
+
+	items, err := svc.DriftingDependencyNames(context.Background(), "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Join(items, ",") != "lodash,react,zod" {
+		t.Fatalf("unexpected items: %#v", items)
+	}
+}
@@ -0,0 +1,192 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"ordo/internal/domain"
+	"ordo/internal/ports"
+)
+
+type CatalogConsolidateRequest struct {
+	// Packages limits consolidation to these packages; empty means every
+	// package whose range differs between workspaces.
+	Packages []string
+	Force    bool
+	// Chooser picks each range interactively; nil keeps the proposed range.
+	Chooser ports.RangeChooser
+}
+
+// CatalogConsolidation is one package moved into the default catalog, or
+// skipped with a reason.
+type CatalogConsolidation struct {
+	Package string
+	Range   string
+	// Workspaces maps each workspace key, "." for the root, to the range it
+	// declared before consolidation.
+	Workspaces map[string]string
+	Skipped    string
+}
+
+func (u CatalogUseCase) RunConsolidate(ctx context.Context, req CatalogConsolidateRequest) ([]CatalogConsolidation, error) {
+	snapshot, err := u.discovery.Snapshot(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if !domain.SupportsCatalogs(snapshot.Manager) {
+		return nil, fmt.Errorf("%w: %s", ErrCatalogUnsupported, snapshot.Manager)
+	}
+
+	workspaces := append([]domain.PackageInfo{snapshot.Root}, sortedWorkspaceInfos(snapshot.ByWorkspace)...)
+	usage, sections := dependencyRangeUsage(workspaces)
+	packages, err := driftingPackages(usage, req.Packages)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]CatalogConsolidation, 0, len(packages))
+	entries := map[string]string{}
+	for _, pkg := range packages {
+		result := CatalogConsolidation{Package: pkg, Workspaces: usage[pkg]}
+		options := distinctRanges(usage[pkg])
+		proposed, _ := domain.HighestRange(options)
+
+		chosen := proposed
+		if req.Chooser != nil {
+			chosen, err = req.Chooser.ChooseRange(ctx, pkg, options, proposed)
+			if err != nil {
+				return nil, err
+			}
+			chosen = strings.TrimSpace(chosen)
+			if chosen == "" {
+				result.Skipped = "skipped by user"
+			} else if _, err := domain.ParseRange(chosen); err != nil {
+				return nil, fmt.Errorf("%s: %w", pkg, err)
+			}
+		} else if chosen == "" {
+			result.Skipped = "no single-version range to propose; pick one with --interactive"
+		}
+
+		if result.Skipped == "" {
+			result.Range = chosen
+			entries[pkg] = chosen
+		}
+		results = append(results, result)
+	}
+	if len(entries) == 0 {
+		return results, nil
+	}
+
+	if err := u.catalogs.UpsertCatalogEntries(ctx, snapshot.Manager, "", entries, req.Force); err != nil {
+		if strings.Contains(err.Error(), "catalog conflict") {
+			return nil, fmt.Errorf("%w: %v", ErrCatalogConflict, err)
+		}
+		return nil, err
+	}
+	ReportFrom(ctx).addPackages(sortedPackageNames(entries)...)
+
+	// Only the section whose range was compared is rewritten, so other
+	// sections such as a wide peer range are left alone.
+	for _, workspace := range workspaces {
+		key := workspaceUsageKey(workspace)
+		for _, section := range consolidatedSections {
+			rewrite := []string{}
+			for _, pkg := range sortedPackageNames(entries) {
+				if used, ok := sections[pkg][key]; ok && used == section {
+					rewrite = append(rewrite, pkg)
+				}
+			}
+			if len(rewrite) == 0 {
+				continue
+			}
+			if err := u.manifests.RewriteSectionCatalogReferencesExistingOnly(ctx, workspace.Dir, section, "", rewrite); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return results, nil
+}
+
+// consolidatedSections are the dependency sections consolidate compares.
+// Peer and optional ranges state compatibility rather than what a workspace
+// installs, so they keep their own ranges.
+var consolidatedSections = []domain.PresetBucket{domain.BucketDependencies, domain.BucketDevDependencies}
+
+// dependencyRangeUsage maps each package to the semver range every workspace
+// declares for it in dependencies or devDependencies, along with the section
+// that range came from; dependencies win when a workspace lists both.
+// Catalog references and non-semver specs are left out.
+func dependencyRangeUsage(workspaces []domain.PackageInfo) (map[string]map[string]string, map[string]map[string]domain.PresetBucket) {
+	usage := map[string]map[string]string{}
+	sections := map[string]map[string]domain.PresetBucket{}
+	for _, workspace := range workspaces {
+		key := workspaceUsageKey(workspace)
+		for _, section := range consolidatedSections {
+			for pkg, version := range workspace.DependencyBuckets[section] {
+				if _, ok := domain.ParseCatalogReference(version); ok {
+					continue
+				}
+				if _, err := domain.ParseRange(version); err != nil {
+					continue
+				}
+				if _, ok := usage[pkg][key]; ok {
+					continue
+				}
+				if usage[pkg] == nil {
+					usage[pkg] = map[string]string{}
+					sections[pkg] = map[string]domain.PresetBucket{}
+				}
+				usage[pkg][key] = strings.TrimSpace(version)
+				sections[pkg][key] = section
+			}
+		}
+	}
+	return usage, sections
+}
+
+func driftingPackages(usage map[string]map[string]string, rawPackages []string) ([]string, error) {
+	requested := trimNonEmpty(rawPackages)
+	if len(requested) > 0 {
+		for _, pkg := range requested {
+			if _, ok := usage[pkg]; !ok {
+				return nil, fmt.Errorf("%w: %s", ErrPackageNotFound, pkg)
+			}
+			if len(distinctRanges(usage[pkg])) < 2 {
+				return nil, fmt.Errorf("%s does not have differing ranges across workspaces", pkg)
+			}
+		}
+		sort.Strings(requested)
+		return slices.Compact(requested), nil
+	}
+
+	packages := []string{}
+	for pkg, ranges := range usage {
+		if len(distinctRanges(ranges)) > 1 {
+			packages = append(packages, pkg)
+		}
+	}
+	sort.Strings(packages)
+	return packages, nil
+}
+
+func distinctRanges(ranges map[string]string) []string {
+	out := make([]string, 0, len(ranges))
+	for _, version := range ranges {
+		if !slices.Contains(out, version) {
+			out = append(out, version)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+func workspaceUsageKey(pkg domain.PackageInfo) string {
+	if pkg.WorkspaceKey == "" {
+		return domain.RootWorkspace
+	}
+	return pkg.WorkspaceKey
+}
@@ -0,0 +1,165 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+
+	"ordo/internal/domain"
+)
+
+type CatalogInlineRequest struct {
+	// Packages limits inlining to these packages; empty inlines every
+	// catalog reference.
+	Packages []string
+	// Remove drops the inlined entries from their catalogs afterwards.
+	Remove bool
+}
+
+// CatalogInlining is one catalog entry written back into the manifests that
+// referenced it.
+type CatalogInlining struct {
+	Catalog    string
+	Package    string
+	Version    string
+	Workspaces []string
+	Removed    bool
+}
+
+func (u CatalogUseCase) RunInline(ctx context.Context, req CatalogInlineRequest) ([]CatalogInlining, error) {
+	snapshot, err := u.discovery.Snapshot(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if !domain.SupportsCatalogs(snapshot.Manager) {
+		return nil, fmt.Errorf("%w: %s (pass --manager with the manager that owns the catalog)", ErrCatalogUnsupported, snapshot.Manager)
+	}
+
+	named, err := u.catalogs.NamedCatalogs(ctx, snapshot.Manager)
+	if err != nil {
+		return nil, err
+	}
+	catalogs := map[string]map[string]string{}
+	for _, name := range append([]string{""}, named...) {
+		entries, err := u.catalogs.CatalogEntries(ctx, snapshot.Manager, name)
+		if err != nil {
+			return nil, err
+		}
+		catalogs[name] = entries
+	}
+
+	requested := trimNonEmpty(req.Packages)
+	matched := map[string]bool{}
+	for _, pkg := range requested {
+		matched[pkg] = false
+	}
+
+	// Resolve every reference before writing so a dangling one fails cleanly.
+	workspaces := append([]domain.PackageInfo{snapshot.Root}, sortedWorkspaceInfos(snapshot.ByWorkspace)...)
+	byWorkspace := map[string]map[string]map[string]string{}
+	inlined := map[catalogPackageKey]*CatalogInlining{}
+	for _, workspace := range workspaces {
+		key := workspaceUsageKey(workspace)
+		for _, raw := range domain.SupportedPresetBuckets() {
+			deps := workspace.DependencyBuckets[domain.PresetBucket(raw)]
+			for _, pkg := range sortedPackageNames(deps) {
+				name, ok := domain.ParseCatalogReference(deps[pkg])
+				if !ok {
+					continue
+				}
+				if _, wanted := matched[pkg]; len(requested) > 0 && !wanted {
+					continue
+				}
+				matched[pkg] = true
+
+				entries, ok := catalogs[name]
+				if !ok {
+					return nil, fmt.Errorf("%w: %s referenced by %s/%s", ErrCatalogNotFound, name, key, pkg)
+				}
+				version, ok := entries[pkg]
+				if !ok {
+					return nil, fmt.Errorf("%w: %s catalog has no entry for %s referenced by %s", ErrPackageNotFound, catalogLabel(name), pkg, key)
+				}
+
+				if byWorkspace[key] == nil {
+					byWorkspace[key] = map[string]map[string]string{}
+				}
+				if byWorkspace[key][name] == nil {
+					byWorkspace[key][name] = map[string]string{}
+				}
+				byWorkspace[key][name][pkg] = version
+
+				ref := catalogPackageKey{catalog: name, pkg: pkg}
+				if inlined[ref] == nil {
+					inlined[ref] = &CatalogInlining{Catalog: name, Package: pkg, Version: version}
+				}
+				if !slices.Contains(inlined[ref].Workspaces, key) {
+					inlined[ref].Workspaces = append(inlined[ref].Workspaces, key)
+				}
+			}
+		}
+	}
+
+	for _, pkg := range requested {
+		if !matched[pkg] {
+			return nil, fmt.Errorf("%w: no workspace references %s through a catalog", ErrPackageNotFound, pkg)
+		}
+	}
+
+	for _, workspace := range workspaces {
+		versions := byWorkspace[workspaceUsageKey(workspace)]
+		if len(versions) == 0 {
+			continue
+		}
+		if err := u.manifests.InlineCatalogReferences(ctx, workspace.Dir, versions); err != nil {
+			return nil, err
+		}
+	}
+
+	results := make([]CatalogInlining, 0, len(inlined))
+	for _, item := range inlined {
+		results = append(results, *item)
+	}
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Catalog != results[j].Catalog {
+			return results[i].Catalog < results[j].Catalog
+		}
+		return results[i].Package < results[j].Package
+	})
+
+	if req.Remove {
+		removals := map[string][]string{}
+		for i := range results {
+			removals[results[i].Catalog] = append(removals[results[i].Catalog], results[i].Package)
+			results[i].Removed = true
+		}
+		for _, name := range sortedCatalogNames(removals) {
+			if err := u.catalogs.RemoveCatalogEntries(ctx, snapshot.Manager, name, removals[name]); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	for _, item := range results {
+		ReportFrom(ctx).addPackages(item.Package)
+	}
+	return results, nil
+}
+
+func sortedCatalogNames[T any](items map[string]T) []string {
+	names := make([]string, 0, len(items))
+	for name := range items {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func catalogLabel(name string) string {
+	if name == "" {
+		return "default"
+	}
+	return name
+}
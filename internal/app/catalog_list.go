@@ -0,0 +1,133 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"ordo/internal/domain"
+)
+
+type CatalogListRequest struct {
+	// Names limits the listing to these catalogs; empty names the default catalog.
+	Names []string
+	// NamedOnly skips the default catalog when Names is empty.
+	NamedOnly bool
+}
+
+// CatalogListing is one catalog and its entries, sorted by package.
+type CatalogListing struct {
+	Name    string
+	Entries []CatalogListEntry
+}
+
+// CatalogListEntry is a catalog package with the workspaces referencing it.
+type CatalogListEntry struct {
+	Package    string
+	Version    string
+	Workspaces []string
+}
+
+func (u CatalogUseCase) RunList(ctx context.Context, req CatalogListRequest) ([]CatalogListing, error) {
+	snapshot, err := u.discovery.Snapshot(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if !domain.SupportsCatalogs(snapshot.Manager) {
+		return nil, fmt.Errorf("%w: %s", ErrCatalogUnsupported, snapshot.Manager)
+	}
+
+	named, err := u.catalogs.NamedCatalogs(ctx, snapshot.Manager)
+	if err != nil {
+		return nil, err
+	}
+	names, err := selectCatalogNames(named, req)
+	if err != nil {
+		return nil, err
+	}
+
+	references := catalogReferences(snapshot)
+	listings := make([]CatalogListing, 0, len(names))
+	for _, name := range names {
+		entries, err := u.catalogs.CatalogEntries(ctx, snapshot.Manager, name)
+		if err != nil {
+			return nil, err
+		}
+		listing := CatalogListing{Name: name, Entries: make([]CatalogListEntry, 0, len(entries))}
+		for _, pkg := range sortedPackageNames(entries) {
+			listing.Entries = append(listing.Entries, CatalogListEntry{
+				Package:    pkg,
+				Version:    entries[pkg],
+				Workspaces: references[catalogPackageKey{catalog: name, pkg: pkg}],
+			})
+		}
+		listings = append(listings, listing)
+	}
+	return listings, nil
+}
+
+func selectCatalogNames(named []string, req CatalogListRequest) ([]string, error) {
+	if len(req.Names) == 0 {
+		if req.NamedOnly {
+			return named, nil
+		}
+		return append([]string{""}, named...), nil
+	}
+
+	names := make([]string, 0, len(req.Names))
+	for _, raw := range req.Names {
+		name := strings.TrimSpace(raw)
+		if name != "" && !slices.Contains(named, name) {
+			return nil, fmt.Errorf("%w: %s", ErrCatalogNotFound, name)
+		}
+		names = append(names, name)
+	}
+	return names, nil
+}
+
+type catalogPackageKey struct {
+	catalog string
+	pkg     string
+}
+
+// catalogReferences maps each catalog package to the sorted keys of the
+// workspaces whose manifests reference it. The root is listed as ".".
+func catalogReferences(snapshot Snapshot) map[catalogPackageKey][]string {
+	references := map[catalogPackageKey][]string{}
+	add := func(key string, pkg domain.PackageInfo) {
+		eachDependency(pkg, func(_ domain.PresetBucket, dep string, version string) {
+			name, ok := domain.ParseCatalogReference(version)
+			if !ok {
+				return
+			}
+			ref := catalogPackageKey{catalog: name, pkg: dep}
+			references[ref] = append(references[ref], key)
+		})
+	}
+
+	add(domain.RootWorkspace, snapshot.Root)
+	for _, pkg := range sortedWorkspaceInfos(snapshot.ByWorkspace) {
+		add(pkg.WorkspaceKey, pkg)
+	}
+	for key := range references {
+		sort.Strings(references[key])
+		references[key] = slices.Compact(references[key])
+	}
+	return references
+}
+
+// eachDependency calls fn for every dependency of pkg section by section, in
+// schema order. A package listed in several sections is visited once per
+// section, unlike the merged DependencyVersions map.
+func eachDependency(pkg domain.PackageInfo, fn func(bucket domain.PresetBucket, name string, spec string)) {
+	for _, raw := range domain.SupportedPresetBuckets() {
+		bucket := domain.PresetBucket(raw)
+		deps := pkg.DependencyBuckets[bucket]
+		for _, name := range sortedPackageNames(deps) {
+			fn(bucket, name, deps[name])
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"ordo/internal/domain"
+)
+
+type CatalogMigrateRequest struct {
+	To domain.PackageManager
+	// Force overwrites target entries whose version differs.
+	Force bool
+}
+
+// CatalogMigration lists the catalogs moved from one manager's file to another.
+type CatalogMigration struct {
+	From     domain.PackageManager
+	To       domain.PackageManager
+	Catalogs []CatalogListing
+}
+
+// RunMigrate moves the default and named catalogs from the detected manager's
+// file to the target manager's file. Workspace manifests keep their catalog:
+// references, which every catalog-aware manager resolves the same way.
+func (u CatalogUseCase) RunMigrate(ctx context.Context, req CatalogMigrateRequest) (CatalogMigration, error) {
+	snapshot, err := u.discovery.Snapshot(ctx)
+	if err != nil {
+		return CatalogMigration{}, err
+	}
+
+	from, to := snapshot.Manager, req.To
+	if !domain.SupportsCatalogs(from) {
+		return CatalogMigration{}, fmt.Errorf("%w: %s (pass --manager with the manager that owns the catalog)", ErrCatalogUnsupported, from)
+	}
+	if !domain.SupportsCatalogs(to) {
+		return CatalogMigration{}, fmt.Errorf("%w: %s (use catalog inline to drop catalogs instead)", ErrCatalogUnsupported, to)
+	}
+	if from == to {
+		return CatalogMigration{}, fmt.Errorf("catalogs already belong to %s", to)
+	}
+
+	named, err := u.catalogs.NamedCatalogs(ctx, from)
+	if err != nil {
+		return CatalogMigration{}, err
+	}
+
+	migration := CatalogMigration{From: from, To: to}
+	conflicts := []string{}
+	for _, name := range append([]string{""}, named...) {
+		entries, err := u.catalogs.CatalogEntries(ctx, from, name)
+		if err != nil {
+			return CatalogMigration{}, err
+		}
+		if len(entries) == 0 {
+			continue
+		}
+
+		existing, err := u.catalogs.CatalogEntries(ctx, to, name)
+		if err != nil {
+			return CatalogMigration{}, err
+		}
+		listing := CatalogListing{Name: name}
+		for _, pkg := range sortedPackageNames(entries) {
+			if current, ok := existing[pkg]; ok && current != entries[pkg] && !req.Force {
+				conflicts = append(conflicts, fmt.Sprintf("%s/%s: existing=%s want=%s", catalogLabel(name), pkg, current, entries[pkg]))
+			}
+			listing.Entries = append(listing.Entries, CatalogListEntry{Package: pkg, Version: entries[pkg]})
+		}
+		migration.Catalogs = append(migration.Catalogs, listing)
+	}
+	if len(conflicts) > 0 {
+		return CatalogMigration{}, fmt.Errorf("%w: %s already has different versions for %s", ErrCatalogConflict, to, strings.Join(conflicts, ", "))
+	}
+
+	// Write every target catalog before touching the source so a failure
+	// never leaves entries in neither file.
+	for _, listing := range migration.Catalogs {
+		entries := make(map[string]string, len(listing.Entries))
+		for _, entry := range listing.Entries {
+			entries[entry.Package] = entry.Version
+		}
+		if err := u.catalogs.UpsertCatalogEntries(ctx, to, listing.Name, entries, true); err != nil {
+			return CatalogMigration{}, err
+		}
+		ReportFrom(ctx).addPackages(sortedPackageNames(entries)...)
+	}
+	for _, listing := range migration.Catalogs {
+		packages := make([]string, 0, len(listing.Entries))
+		for _, entry := range listing.Entries {
+			packages = append(packages, entry.Package)
+		}
+		if err := u.catalogs.RemoveCatalogEntries(ctx, from, listing.Name, packages); err != nil {
+			return CatalogMigration{}, err
+		}
+	}
+	return migration, nil
+}
@@ -0,0 +1,203 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"ordo/internal/domain"
+)
+
+type CatalogRenameRequest struct {
+	From string
+	To   string
+}
+
+// CatalogRename is a named catalog renamed along with the workspaces whose
+// references were rewritten.
+type CatalogRename struct {
+	From       string
+	To         string
+	Workspaces []string
+}
+
+type CatalogCopyRequest struct {
+	From string
+	To   string
+}
+
+// CatalogCopy is a named catalog duplicated under a new name.
+type CatalogCopy struct {
+	From    string
+	To      string
+	Entries int
+}
+
+type CatalogDeleteRequest struct {
+	Name string
+	// Force inlines remaining references before deleting the catalog.
+	Force bool
+}
+
+// CatalogDeletion is a deleted named catalog and the workspaces whose
+// references were inlined first.
+type CatalogDeletion struct {
+	Name       string
+	Entries    int
+	Workspaces []string
+}
+
+func (u CatalogUseCase) RunRename(ctx context.Context, req CatalogRenameRequest) (CatalogRename, error) {
+	snapshot, from, to, err := u.namedCatalogPair(ctx, req.From, req.To)
+	if err != nil {
+		return CatalogRename{}, err
+	}
+
+	result := CatalogRename{From: from, To: to, Workspaces: []string{}}
+	if err := u.catalogs.RenameCatalog(ctx, snapshot.Manager, from, to); err != nil {
+		return CatalogRename{}, err
+	}
+	for _, workspace := range workspacesReferencingCatalog(snapshot, from) {
+		if err := u.manifests.RenameCatalogReferences(ctx, workspace.Dir, from, to); err != nil {
+			return CatalogRename{}, err
+		}
+		result.Workspaces = append(result.Workspaces, workspaceUsageKey(workspace))
+	}
+	return result, nil
+}
+
+func (u CatalogUseCase) RunCopy(ctx context.Context, req CatalogCopyRequest) (CatalogCopy, error) {
+	snapshot, from, to, err := u.namedCatalogPair(ctx, req.From, req.To)
+	if err != nil {
+		return CatalogCopy{}, err
+	}
+
+	entries, err := u.catalogs.CatalogEntries(ctx, snapshot.Manager, from)
+	if err != nil {
+		return CatalogCopy{}, err
+	}
+	if err := u.catalogs.UpsertCatalogEntries(ctx, snapshot.Manager, to, entries, false); err != nil {
+		return CatalogCopy{}, err
+	}
+	ReportFrom(ctx).addPackages(sortedPackageNames(entries)...)
+	return CatalogCopy{From: from, To: to, Entries: len(entries)}, nil
+}
+
+func (u CatalogUseCase) RunDelete(ctx context.Context, req CatalogDeleteRequest) (CatalogDeletion, error) {
+	if err := domain.ValidateCatalogName(req.Name); err != nil {
+		return CatalogDeletion{}, fmt.Errorf("%w: %v", ErrInvalidCatalogName, err)
+	}
+	name := strings.TrimSpace(req.Name)
+
+	snapshot, err := u.namedCatalogSnapshot(ctx, name)
+	if err != nil {
+		return CatalogDeletion{}, err
+	}
+
+	workspaces := workspacesReferencingCatalog(snapshot, name)
+	keys := make([]string, 0, len(workspaces))
+	for _, workspace := range workspaces {
+		keys = append(keys, workspaceUsageKey(workspace))
+	}
+	if len(workspaces) > 0 && !req.Force {
+		return CatalogDeletion{}, fmt.Errorf("%w: %s is referenced by %s; pass --force to inline those references", ErrCatalogInUse, name, strings.Join(keys, ", "))
+	}
+
+	entries, err := u.catalogs.CatalogEntries(ctx, snapshot.Manager, name)
+	if err != nil {
+		return CatalogDeletion{}, err
+	}
+	// A reference the catalog cannot resolve would be left dangling once the
+	// catalog is gone, so fail before writing anything.
+	for _, workspace := range workspaces {
+		var missing []string
+		eachDependency(workspace, func(_ domain.PresetBucket, pkg string, version string) {
+			if ref, ok := domain.ParseCatalogReference(version); ok && ref == name && !hasCatalogEntry(entries, pkg) {
+				missing = append(missing, pkg)
+			}
+		})
+		if len(missing) > 0 {
+			sort.Strings(missing)
+			return CatalogDeletion{}, fmt.Errorf("%w: %s catalog has no entry for %s referenced by %s", ErrPackageNotFound, catalogLabel(name), strings.Join(missing, ", "), workspaceUsageKey(workspace))
+		}
+	}
+	for _, workspace := range workspaces {
+		if err := u.manifests.InlineCatalogReferences(ctx, workspace.Dir, map[string]map[string]string{name: entries}); err != nil {
+			return CatalogDeletion{}, err
+		}
+	}
+	if err := u.catalogs.DeleteCatalog(ctx, snapshot.Manager, name); err != nil {
+		return CatalogDeletion{}, err
+	}
+	ReportFrom(ctx).addPackages(sortedPackageNames(entries)...)
+	return CatalogDeletion{Name: name, Entries: len(entries), Workspaces: keys}, nil
+}
+
+// namedCatalogPair validates a source catalog that must exist and a target
+// name that must not.
+func (u CatalogUseCase) namedCatalogPair(ctx context.Context, rawFrom string, rawTo string) (Snapshot, string, string, error) {
+	for _, raw := range []string{rawFrom, rawTo} {
+		if err := domain.ValidateCatalogName(raw); err != nil {
+			return Snapshot{}, "", "", fmt.Errorf("%w: %v", ErrInvalidCatalogName, err)
+		}
+	}
+	from, to := strings.TrimSpace(rawFrom), strings.TrimSpace(rawTo)
+
+	snapshot, err := u.namedCatalogSnapshot(ctx, from)
+	if err != nil {
+		return Snapshot{}, "", "", err
+	}
+	named, err := u.catalogs.NamedCatalogs(ctx, snapshot.Manager)
+	if err != nil {
+		return Snapshot{}, "", "", err
+	}
+	if slices.Contains(named, to) {
+		return Snapshot{}, "", "", fmt.Errorf("%w: catalog %s already exists", ErrCatalogConflict, to)
+	}
+	return snapshot, from, to, nil
+}
+
+func (u CatalogUseCase) namedCatalogSnapshot(ctx context.Context, name string) (Snapshot, error) {
+	snapshot, err := u.discovery.Snapshot(ctx)
+	if err != nil {
+		return Snapshot{}, err
+	}
+
+	if !domain.SupportsCatalogs(snapshot.Manager) {
+		return Snapshot{}, fmt.Errorf("%w: %s", ErrCatalogUnsupported, snapshot.Manager)
+	}
+
+	named, err := u.catalogs.NamedCatalogs(ctx, snapshot.Manager)
+	if err != nil {
+		return Snapshot{}, err
+	}
+	if !slices.Contains(named, name) {
+		return Snapshot{}, fmt.Errorf("%w: %s", ErrCatalogNotFound, name)
+	}
+	return snapshot, nil
+}
+
+// workspacesReferencingCatalog returns the root and nested workspaces with a
+// dependency on catalog:<name>, root first.
+func workspacesReferencingCatalog(snapshot Snapshot, name string) []domain.PackageInfo {
+	out := []domain.PackageInfo{}
+	for _, workspace := range append([]domain.PackageInfo{snapshot.Root}, sortedWorkspaceInfos(snapshot.ByWorkspace)...) {
+		referenced := false
+		eachDependency(workspace, func(_ domain.PresetBucket, _ string, version string) {
+			if ref, ok := domain.ParseCatalogReference(version); ok && ref == name {
+				referenced = true
+			}
+		})
+		if referenced {
+			out = append(out, workspace)
+		}
+	}
+	return out
+}
+
+func hasCatalogEntry(entries map[string]string, pkg string) bool {
+	_, ok := entries[pkg]
+	return ok
+}
@@ -0,0 +1,367 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"ordo/internal/domain"
+)
+
+// metadataFetchConcurrency bounds parallel registry lookups.
+const metadataFetchConcurrency = 8
+
+type CatalogOutdatedRequest struct{}
+
+// CatalogOutdatedEntry is a catalog entry whose range excludes the registry's
+// latest version or pins a lower minimum than it, or one that was skipped.
+type CatalogOutdatedEntry struct {
+	Catalog string
+	Package string
+	Range   string
+	// Wanted is the highest published version inside Range, empty if none.
+	Wanted string
+	Latest string
+	// Skipped explains why the entry could not be checked; the version
+	// fields are then empty.
+	Skipped string
+}
+
+type CatalogUpgradeRequest struct {
+	Packages []string
+	// Level caps how far entries move; empty means major.
+	Level domain.UpgradeLevel
+}
+
+// CatalogUpgrade is one catalog entry rewritten, or skipped with a reason.
+type CatalogUpgrade struct {
+	Catalog string
+	Package string
+	From    string
+	To      string
+	Skipped string
+}
+
+type catalogEntry struct {
+	catalog string
+	pkg     string
+	version string
+}
+
+func (u CatalogUseCase) RunOutdated(ctx context.Context, _ CatalogOutdatedRequest) ([]CatalogOutdatedEntry, error) {
+	snapshot, err := u.discovery.Snapshot(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if !domain.SupportsCatalogs(snapshot.Manager) {
+		return nil, fmt.Errorf("%w: %s", ErrCatalogUnsupported, snapshot.Manager)
+	}
+
+	entries, err := u.allCatalogEntries(ctx, snapshot.Manager)
+	if err != nil {
+		return nil, err
+	}
+	entries = semverCatalogEntries(entries)
+
+	metadata, err := u.fetchMetadata(ctx, catalogEntryPackages(entries))
+	if err != nil {
+		return nil, err
+	}
+
+	outdated := []CatalogOutdatedEntry{}
+	for _, entry := range entries {
+		info, found := metadata[entry.pkg]
+		if !found {
+			outdated = append(outdated, CatalogOutdatedEntry{
+				Catalog: entry.catalog,
+				Package: entry.pkg,
+				Range:   entry.version,
+				Skipped: unpublishedReason,
+			})
+			continue
+		}
+		versions, latest, ok := publishedVersions(info)
+		if !ok {
+			continue
+		}
+		rng, _ := domain.ParseRange(entry.version)
+
+		stale := !rng.Satisfies(latest)
+		if _, base, ok := domain.SimpleRange(entry.version); ok && base.Compare(latest) < 0 {
+			stale = true
+		}
+		if !stale {
+			continue
+		}
+
+		item := CatalogOutdatedEntry{
+			Catalog: entry.catalog,
+			Package: entry.pkg,
+			Range:   entry.version,
+			Latest:  latest.String(),
+		}
+		if wanted, ok := rng.MaxSatisfying(versions); ok {
+			item.Wanted = wanted.String()
+		}
+		outdated = append(outdated, item)
+	}
+	return outdated, nil
+}
+
+func (u CatalogUseCase) RunUpgrade(ctx context.Context, req CatalogUpgradeRequest) ([]CatalogUpgrade, error) {
+	snapshot, err := u.discovery.Snapshot(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if !domain.SupportsCatalogs(snapshot.Manager) {
+		return nil, fmt.Errorf("%w: %s", ErrCatalogUnsupported, snapshot.Manager)
+	}
+
+	level := req.Level
+	if level == "" {
+		level = domain.UpgradeMajor
+	}
+
+	entries, err := u.allCatalogEntries(ctx, snapshot.Manager)
+	if err != nil {
+		return nil, err
+	}
+	entries, err = filterCatalogEntries(entries, req.Packages)
+	if err != nil {
+		return nil, err
+	}
+
+	results := []CatalogUpgrade{}
+	candidates := make([]catalogEntry, 0, len(entries))
+	for _, entry := range entries {
+		if _, _, ok := domain.SimpleRange(entry.version); !ok {
+			results = append(results, CatalogUpgrade{
+				Catalog: entry.catalog,
+				Package: entry.pkg,
+				From:    entry.version,
+				Skipped: "range is not a single version with an operator",
+			})
+			continue
+		}
+		candidates = append(candidates, entry)
+	}
+
+	metadata, err := u.fetchMetadata(ctx, catalogEntryPackages(candidates))
+	if err != nil {
+		return nil, err
+	}
+
+	changes := map[string]map[string]string{}
+	for _, entry := range candidates {
+		info, found := metadata[entry.pkg]
+		if !found {
+			results = append(results, CatalogUpgrade{
+				Catalog: entry.catalog,
+				Package: entry.pkg,
+				From:    entry.version,
+				Skipped: unpublishedReason,
+			})
+			continue
+		}
+		versions, latest, ok := publishedVersions(info)
+		if !ok {
+			continue
+		}
+		op, base, _ := domain.SimpleRange(entry.version)
+		target, ok := domain.UpgradeTarget(base, latest, versions, level)
+		if !ok {
+			continue
+		}
+
+		next := op + target.String()
+		if changes[entry.catalog] == nil {
+			changes[entry.catalog] = map[string]string{}
+		}
+		changes[entry.catalog][entry.pkg] = next
+		results = append(results, CatalogUpgrade{
+			Catalog: entry.catalog,
+			Package: entry.pkg,
+			From:    entry.version,
+			To:      next,
+		})
+	}
+
+	names := make([]string, 0, len(changes))
+	for name := range changes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if err := u.catalogs.UpsertCatalogEntries(ctx, snapshot.Manager, name, changes[name], true); err != nil {
+			return nil, err
+		}
+		ReportFrom(ctx).addPackages(sortedPackageNames(changes[name])...)
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Catalog != results[j].Catalog {
+			return results[i].Catalog < results[j].Catalog
+		}
+		return results[i].Package < results[j].Package
+	})
+	return results, nil
+}
+
+// allCatalogEntries lists the default catalog followed by every named catalog,
+// each sorted by package.
+func (u CatalogUseCase) allCatalogEntries(ctx context.Context, manager domain.PackageManager) ([]catalogEntry, error) {
+	named, err := u.catalogs.NamedCatalogs(ctx, manager)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := []catalogEntry{}
+	for _, name := range append([]string{""}, named...) {
+		versions, err := u.catalogs.CatalogEntries(ctx, manager, name)
+		if err != nil {
+			return nil, err
+		}
+		for _, pkg := range sortedPackageNames(versions) {
+			entries = append(entries, catalogEntry{catalog: name, pkg: pkg, version: versions[pkg]})
+		}
+	}
+	return entries, nil
+}
+
+// unpublishedReason explains entries skipped because the registry does not
+// know their package.
+const unpublishedReason = "package not found in the registry"
+
+// fetchMetadata looks up every package in the registry, a few at a time.
+// Packages the registry does not know are left out of the result; any other
+// error cancels the lookups still running and is returned.
+func (u CatalogUseCase) fetchMetadata(ctx context.Context, packages []string) (map[string]domain.PackageMetadata, error) {
+	if len(packages) == 0 {
+		return map[string]domain.PackageMetadata{}, nil
+	}
+	if u.versions == nil {
+		return nil, fmt.Errorf("package version resolver is not configured")
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		firstErr error
+	)
+	out := make(map[string]domain.PackageMetadata, len(packages))
+	slots := make(chan struct{}, metadataFetchConcurrency)
+	for _, pkg := range packages {
+		select {
+		case slots <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+		wg.Add(1)
+		go func(pkg string) {
+			defer wg.Done()
+			defer func() { <-slots }()
+
+			metadata, err := u.versions.PackageMetadata(ctx, pkg)
+			mu.Lock()
+			defer mu.Unlock()
+			switch {
+			case errors.Is(err, domain.ErrPackageNotPublished):
+			case err != nil:
+				if firstErr == nil {
+					firstErr = err
+					cancel()
+				}
+			default:
+				out[pkg] = metadata
+			}
+		}(pkg)
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// publishedVersions parses the registry versions and the latest dist-tag,
+// skipping anything that is not valid semver.
+func publishedVersions(metadata domain.PackageMetadata) ([]domain.Version, domain.Version, bool) {
+	latest, err := domain.ParseVersion(metadata.DistTags["latest"])
+	if err != nil {
+		return nil, domain.Version{}, false
+	}
+	versions := make([]domain.Version, 0, len(metadata.Versions))
+	for _, raw := range metadata.Versions {
+		if v, err := domain.ParseVersion(raw); err == nil {
+			versions = append(versions, v)
+		}
+	}
+	return versions, latest, true
+}
+
+// semverCatalogEntries drops entries such as "workspace:*" or "npm:" aliases
+// whose version is not a semver range.
+func semverCatalogEntries(entries []catalogEntry) []catalogEntry {
+	out := make([]catalogEntry, 0, len(entries))
+	for _, entry := range entries {
+		if _, err := domain.ParseRange(entry.version); err != nil {
+			continue
+		}
+		out = append(out, entry)
+	}
+	return out
+}
+
+func filterCatalogEntries(entries []catalogEntry, rawPackages []string) ([]catalogEntry, error) {
+	packages := trimNonEmpty(rawPackages)
+	if len(packages) == 0 {
+		return entries, nil
+	}
+
+	wanted := map[string]bool{}
+	for _, pkg := range packages {
+		wanted[pkg] = false
+	}
+	out := make([]catalogEntry, 0, len(entries))
+	for _, entry := range entries {
+		if _, ok := wanted[entry.pkg]; !ok {
+			continue
+		}
+		wanted[entry.pkg] = true
+		out = append(out, entry)
+	}
+
+	for _, pkg := range packages {
+		if !wanted[pkg] {
+			return nil, fmt.Errorf("%w: %s is not in any catalog", ErrPackageNotFound, pkg)
+		}
+	}
+	return out, nil
+}
+
+func catalogEntryPackages(entries []catalogEntry) []string {
+	seen := map[string]struct{}{}
+	packages := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if _, ok := seen[entry.pkg]; ok {
+			continue
+		}
+		seen[entry.pkg] = struct{}{}
+		packages = append(packages, entry.pkg)
+	}
+	sort.Strings(packages)
+	return packages
+}
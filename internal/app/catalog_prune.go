@@ -0,0 +1,67 @@
+package app
+
+import (
+	"context"
+	"fmt"
+
+	"ordo/internal/domain"
+)
+
+type CatalogPruneRequest struct {
+	// Names limits pruning to these catalogs; empty names the default catalog.
+	Names []string
+	// NamedOnly skips the default catalog when Names is empty.
+	NamedOnly bool
+}
+
+// RunPrune removes catalog entries that no workspace manifest references and
+// returns what it removed, grouped by catalog. Catalogs with nothing to prune
+// are omitted.
+func (u CatalogUseCase) RunPrune(ctx context.Context, req CatalogPruneRequest) ([]CatalogListing, error) {
+	snapshot, err := u.discovery.Snapshot(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if !domain.SupportsCatalogs(snapshot.Manager) {
+		return nil, fmt.Errorf("%w: %s", ErrCatalogUnsupported, snapshot.Manager)
+	}
+
+	named, err := u.catalogs.NamedCatalogs(ctx, snapshot.Manager)
+	if err != nil {
+		return nil, err
+	}
+	names, err := selectCatalogNames(named, CatalogListRequest{Names: req.Names, NamedOnly: req.NamedOnly})
+	if err != nil {
+		return nil, err
+	}
+
+	references := catalogReferences(snapshot)
+	pruned := make([]CatalogListing, 0, len(names))
+	for _, name := range names {
+		entries, err := u.catalogs.CatalogEntries(ctx, snapshot.Manager, name)
+		if err != nil {
+			return nil, err
+		}
+
+		listing := CatalogListing{Name: name}
+		unused := make([]string, 0, len(entries))
+		for _, pkg := range sortedPackageNames(entries) {
+			if len(references[catalogPackageKey{catalog: name, pkg: pkg}]) > 0 {
+				continue
+			}
+			unused = append(unused, pkg)
+			listing.Entries = append(listing.Entries, CatalogListEntry{Package: pkg, Version: entries[pkg]})
+		}
+		if len(unused) == 0 {
+			continue
+		}
+
+		if err := u.catalogs.RemoveCatalogEntries(ctx, snapshot.Manager, name, unused); err != nil {
+			return nil, err
+		}
+		ReportFrom(ctx).addPackages(unused...)
+		pruned = append(pruned, listing)
+	}
+	return pruned, nil
+}
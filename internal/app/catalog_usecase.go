@@ -3,6 +3,7 @@ package app
 import (
 	"context"
 	"fmt"
+	"slices"
 	"sort"
 	"strings"
 
@@ -32,7 +33,25 @@ type CatalogsRemoveRequest struct {
 	Packages []string
 }
 
-type CatalogSyncRequest struct{}
+type CatalogSyncRequest struct {
+	// Names selects named catalogs to sync; empty syncs the default catalog.
+	Names []string
+	// All syncs the default catalog and every named catalog.
+	All bool
+}
+
+// CatalogSyncResult lists the synced catalogs and the packages left alone
+// because more than one catalog defines them.
+type CatalogSyncResult struct {
+	Catalogs  []string
+	Ambiguous []CatalogAmbiguity
+}
+
+// CatalogAmbiguity is a package defined in several catalogs.
+type CatalogAmbiguity struct {
+	Package  string
+	Catalogs []string
+}
 
 type CatalogImportRequest struct {
 	Package       string
@@ -103,32 +122,95 @@ func (u CatalogUseCase) RunNamedRemove(ctx context.Context, req CatalogsRemoveRe
 	return u.applyRemove(ctx, strings.TrimSpace(req.Name), req.Packages)
 }
 
-func (u CatalogUseCase) RunSync(ctx context.Context, _ CatalogSyncRequest) error {
+func (u CatalogUseCase) RunSync(ctx context.Context, req CatalogSyncRequest) (CatalogSyncResult, error) {
 	snapshot, err := u.discovery.Snapshot(ctx)
 	if err != nil {
-		return err
+		return CatalogSyncResult{}, err
 	}
 
 	if !domain.SupportsCatalogs(snapshot.Manager) {
-		return fmt.Errorf("%w: %s", ErrCatalogUnsupported, snapshot.Manager)
+		return CatalogSyncResult{}, fmt.Errorf("%w: %s", ErrCatalogUnsupported, snapshot.Manager)
 	}
 
-	packages, err := u.catalogs.CatalogPackageNames(ctx, snapshot.Manager, "")
+	named, err := u.catalogs.NamedCatalogs(ctx, snapshot.Manager)
 	if err != nil {
-		return err
+		return CatalogSyncResult{}, err
+	}
+	names := []string{""}
+	switch {
+	case req.All:
+		names = append(names, named...)
+	case len(req.Names) > 0:
+		names, err = selectCatalogNames(named, CatalogListRequest{Names: req.Names})
+		if err != nil {
+			return CatalogSyncResult{}, err
+		}
 	}
-	if len(packages) == 0 {
-		return nil
+
+	// A package defined in several of the selected catalogs has no single
+	// reference to sync to, so it is reported instead of rewritten.
+	owners := map[string][]string{}
+	packagesByName := map[string][]string{}
+	for _, name := range names {
+		packages, err := u.catalogs.CatalogPackageNames(ctx, snapshot.Manager, name)
+		if err != nil {
+			return CatalogSyncResult{}, err
+		}
+		packagesByName[name] = packages
+		for _, pkg := range packages {
+			owners[pkg] = append(owners[pkg], name)
+		}
 	}
 
+	result := CatalogSyncResult{Catalogs: names}
+	reported := map[string]bool{}
 	workspaces := sortedWorkspaceInfos(snapshot.ByWorkspace)
-	for _, workspace := range workspaces {
-		if err := u.manifests.RewriteCatalogReferencesExistingOnly(ctx, workspace.Dir, "", packages); err != nil {
-			return err
+	for _, name := range names {
+		packages := make([]string, 0, len(packagesByName[name]))
+		for _, pkg := range packagesByName[name] {
+			if len(owners[pkg]) < 2 {
+				packages = append(packages, pkg)
+				continue
+			}
+			if !reported[pkg] {
+				reported[pkg] = true
+				result.Ambiguous = append(result.Ambiguous, CatalogAmbiguity{Package: pkg, Catalogs: owners[pkg]})
+			}
+		}
+		if len(packages) == 0 {
+			continue
+		}
+
+		for _, workspace := range workspaces {
+			// Entries that already reference another catalog were chosen
+			// on purpose; leave them pointing where they do.
+			other := referencedElsewhere(workspace, name)
+			rewrite := slices.DeleteFunc(slices.Clone(packages), func(pkg string) bool { return other[pkg] })
+			if len(rewrite) == 0 {
+				continue
+			}
+			if err := u.manifests.RewriteCatalogReferencesExistingOnly(ctx, workspace.Dir, name, rewrite); err != nil {
+				return CatalogSyncResult{}, err
+			}
 		}
 	}
 
-	return nil
+	sort.Slice(result.Ambiguous, func(i, j int) bool {
+		return result.Ambiguous[i].Package < result.Ambiguous[j].Package
+	})
+	return result, nil
+}
+
+// referencedElsewhere returns the packages a workspace references through a
+// catalog other than name, in any dependency section.
+func referencedElsewhere(workspace domain.PackageInfo, name string) map[string]bool {
+	other := map[string]bool{}
+	eachDependency(workspace, func(_ domain.PresetBucket, pkg string, version string) {
+		if ref, ok := domain.ParseCatalogReference(version); ok && ref != name {
+			other[pkg] = true
+		}
+	})
+	return other
 }
 
 func (u CatalogUseCase) RunImport(ctx context.Context, req CatalogImportRequest) error {
@@ -159,6 +241,8 @@ func (u CatalogUseCase) RunImport(ctx context.Context, req CatalogImportRequest)
 		return fmt.Errorf("package already uses catalog reference: %s", pkg)
 	}
 
+	ReportFrom(ctx).addPackages(pkg)
+
 	entries := map[string]string{pkg: version}
 	if err := u.catalogs.UpsertCatalogEntries(ctx, snapshot.Manager, "", entries, req.Force); err != nil {
 		if strings.Contains(err.Error(), "catalog conflict") {
@@ -194,6 +278,14 @@ func (u CatalogUseCase) RunPreset(ctx context.Context, req CatalogPresetRequest)
 }
 
 func (u CatalogUseCase) applyAdd(ctx context.Context, name string, rawPackages []string, workspace string, force bool) error {
+	return u.applyAddToSection(ctx, name, rawPackages, workspace, "", force)
+}
+
+// applyAddToSection writes the packages into the catalog and points the
+// target workspace at it. With a section, references are written to that
+// dependency section only; otherwise existing references are rewritten
+// wherever they are and missing ones go to dependencies.
+func (u CatalogUseCase) applyAddToSection(ctx context.Context, name string, rawPackages []string, workspace string, section domain.PresetBucket, force bool) error {
 	snapshot, err := u.discovery.Snapshot(ctx)
 	if err != nil {
 		return err
@@ -221,6 +313,10 @@ func (u CatalogUseCase) applyAdd(ctx context.Context, name string, rawPackages [
 	}
 
 	packages := sortedPackageNames(resolved)
+	ReportFrom(ctx).addPackages(packages...)
+	if section != "" {
+		return u.manifests.RewriteSectionCatalogReferences(ctx, target.Dir, section, name, packages)
+	}
 	return u.manifests.RewriteCatalogReferences(ctx, target.Dir, name, packages)
 }
 
@@ -238,6 +334,7 @@ func (u CatalogUseCase) applyRemove(ctx context.Context, name string, rawPackage
 	if err != nil {
 		return err
 	}
+	ReportFrom(ctx).addPackages(packages...)
 
 	return u.catalogs.RemoveCatalogEntries(ctx, snapshot.Manager, name, packages)
 }
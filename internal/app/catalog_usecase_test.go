@@ -3,7 +3,12 @@ package app
 import (
 	"context"
 	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
 	"testing"
+	"time"
 
 	"ordo/internal/domain"
 )
@@ -17,6 +22,9 @@ type fakeCatalogStore struct {
 	err           error
 	named         []string
 	catalogByName map[string][]string
+	entriesByName map[string]map[string]string
+	removedByName map[string][]string
+	renamed       []string
 }
 
 func (f *fakeCatalogStore) UpsertCatalogEntries(_ context.Context, manager domain.PackageManager, name string, entries map[string]string, force bool) error {
@@ -31,6 +39,22 @@ func (f *fakeCatalogStore) RemoveCatalogEntries(_ context.Context, manager domai
 	f.manager = manager
 	f.name = name
 	f.removed = append([]string(nil), packages...)
+	if f.removedByName == nil {
+		f.removedByName = map[string][]string{}
+	}
+	f.removedByName[name] = f.removed
+	return f.err
+}
+
+func (f *fakeCatalogStore) RenameCatalog(_ context.Context, manager domain.PackageManager, oldName string, newName string) error {
+	f.manager = manager
+	f.renamed = append(f.renamed, oldName+"->"+newName)
+	return f.err
+}
+
+func (f *fakeCatalogStore) DeleteCatalog(_ context.Context, manager domain.PackageManager, name string) error {
+	f.manager = manager
+	f.name = name
 	return f.err
 }
 
@@ -45,12 +69,22 @@ func (f *fakeCatalogStore) CatalogPackageNames(_ context.Context, _ domain.Packa
 	return f.catalogByName[name], f.err
 }
 
+func (f *fakeCatalogStore) CatalogEntries(_ context.Context, _ domain.PackageManager, name string) (map[string]string, error) {
+	if f.entriesByName == nil {
+		return map[string]string{}, f.err
+	}
+	return f.entriesByName[name], f.err
+}
+
 type fakeManifestStore struct {
 	dir      string
 	name     string
 	packages []string
 	sync     []manifestRewriteCall
 	existing []manifestRewriteCall
+	sections []string
+	inlined  map[string]map[string]map[string]string
+	renamed  []string
 	err      error
 }
 
@@ -78,8 +112,32 @@ func (f *fakeManifestStore) RewriteCatalogReferencesExistingOnly(_ context.Conte
 	return f.err
 }
 
+func (f *fakeManifestStore) RewriteSectionCatalogReferences(_ context.Context, targetDir string, section domain.PresetBucket, catalogName string, packages []string) error {
+	f.sections = append(f.sections, fmt.Sprintf("add %s %s catalog:%s %s", targetDir, section, catalogName, strings.Join(packages, ",")))
+	return f.err
+}
+
+func (f *fakeManifestStore) RewriteSectionCatalogReferencesExistingOnly(_ context.Context, targetDir string, section domain.PresetBucket, catalogName string, packages []string) error {
+	f.sections = append(f.sections, fmt.Sprintf("existing %s %s catalog:%s %s", targetDir, section, catalogName, strings.Join(packages, ",")))
+	return f.err
+}
+
+func (f *fakeManifestStore) InlineCatalogReferences(_ context.Context, targetDir string, versions map[string]map[string]string) error {
+	if f.inlined == nil {
+		f.inlined = map[string]map[string]map[string]string{}
+	}
+	f.inlined[targetDir] = versions
+	return f.err
+}
+
+func (f *fakeManifestStore) RenameCatalogReferences(_ context.Context, targetDir string, oldName string, newName string) error {
+	f.renamed = append(f.renamed, fmt.Sprintf("%s %s->%s", targetDir, oldName, newName))
+	return f.err
+}
+
 type fakeVersionResolver struct {
 	versions map[string]string
+	metadata map[string]domain.PackageMetadata
 	err      error
 }
 
@@ -93,6 +151,16 @@ func (f fakeVersionResolver) LatestVersion(_ context.Context, packageName string
 	return "", errors.New("missing")
 }
 
+func (f fakeVersionResolver) PackageMetadata(_ context.Context, packageName string) (domain.PackageMetadata, error) {
+	if f.err != nil {
+		return domain.PackageMetadata{}, f.err
+	}
+	if metadata, ok := f.metadata[packageName]; ok {
+		return metadata, nil
+	}
+	return domain.PackageMetadata{}, fmt.Errorf("%w: %s", domain.ErrPackageNotPublished, packageName)
+}
+
 func TestCatalogUseCaseDefaultCatalogAdd(t *testing.T) {
 	catalogs := &fakeCatalogStore{}
 	manifests := &fakeManifestStore{}
@@ -255,7 +323,7 @@ func TestCatalogUseCaseSync(t *testing.T) {
 	manifests := &fakeManifestStore{}
 	uc := NewCatalogUseCase(NewDiscoveryService(fakeIndexer{infos: fixtureInfos()}), catalogs, manifests, fakeVersionResolver{})
 
-	err := uc.RunSync(context.Background(), CatalogSyncRequest{})
+	_, err := uc.RunSync(context.Background(), CatalogSyncRequest{})
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -278,7 +346,7 @@ func TestCatalogUseCaseSyncNoCatalogEntries(t *testing.T) {
 	manifests := &fakeManifestStore{}
 	uc := NewCatalogUseCase(NewDiscoveryService(fakeIndexer{infos: fixtureInfos()}), catalogs, manifests, fakeVersionResolver{})
 
-	err := uc.RunSync(context.Background(), CatalogSyncRequest{})
+	_, err := uc.RunSync(context.Background(), CatalogSyncRequest{})
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -295,7 +363,7 @@ func TestCatalogUseCaseSyncNoWorkspaces(t *testing.T) {
 	}
 	uc := NewCatalogUseCase(NewDiscoveryService(fakeIndexer{infos: infos}), catalogs, manifests, fakeVersionResolver{})
 
-	err := uc.RunSync(context.Background(), CatalogSyncRequest{})
+	_, err := uc.RunSync(context.Background(), CatalogSyncRequest{})
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -313,7 +381,7 @@ func TestCatalogUseCaseSyncUnsupportedManager(t *testing.T) {
 	}
 	uc := NewCatalogUseCase(NewDiscoveryService(fakeIndexer{infos: infos}), catalogs, manifests, fakeVersionResolver{})
 
-	err := uc.RunSync(context.Background(), CatalogSyncRequest{})
+	_, err := uc.RunSync(context.Background(), CatalogSyncRequest{})
 	if !errors.Is(err, ErrCatalogUnsupported) {
 		t.Fatalf("expected ErrCatalogUnsupported, got %v", err)
 	}
@@ -515,3 +583,1052 @@ func TestCatalogUseCasePresetMissingConfigStore(t *testing.T) {
 		t.Fatal("expected error")
 	}
 }
+
+func TestCatalogUseCaseListReportsReferences(t *testing.T) {
+	infos := fixtureInfos()
+	infos[0].DependencyVersions["react"] = "catalog:"
+	infos[1].DependencyVersions["clsx"] = "catalog:legacy"
+	infos[1].DependencyVersions["react"] = "catalog:default"
+	catalogs := &fakeCatalogStore{
+		named: []string{"legacy"},
+		entriesByName: map[string]map[string]string{
+			"":       {"react": "^19.0.0", "zod": "^3.23.0"},
+			"legacy": {"clsx": "^1.2.1"},
+		},
+	}
+	uc := NewCatalogUseCase(NewDiscoveryService(fakeIndexer{infos: infos}), catalogs, &fakeManifestStore{}, fakeVersionResolver{})
+
+	listings, err := uc.RunList(context.Background(), CatalogListRequest{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(listings) != 2 || listings[0].Name != "" || listings[1].Name != "legacy" {
+		t.Fatalf("unexpected listings: %#v", listings)
+	}
+
+	react := listings[0].Entries[0]
+	if react.Package != "react" || react.Version != "^19.0.0" || strings.Join(react.Workspaces, ",") != ".,ui" {
+		t.Fatalf("unexpected react entry: %#v", react)
+	}
+	if zod := listings[0].Entries[1]; zod.Package != "zod" || len(zod.Workspaces) != 0 {
+		t.Fatalf("unexpected zod entry: %#v", zod)
+	}
+	if clsx := listings[1].Entries[0]; strings.Join(clsx.Workspaces, ",") != "ui" {
+		t.Fatalf("unexpected clsx entry: %#v", clsx)
+	}
+}
+
+func TestCatalogUseCaseListCountsReferencesInEverySection(t *testing.T) {
+	infos := fixtureInfos()
+	infos[0].DependencyBuckets = map[domain.PresetBucket]map[string]string{
+		domain.BucketDependencies:         {"react": "^19.0.0"},
+		domain.BucketOptionalDependencies: {"react": "catalog:peers"},
+	}
+	infos[1].DependencyBuckets = map[domain.PresetBucket]map[string]string{
+		domain.BucketPeerDependencies: {"react": "catalog:peers"},
+	}
+	catalogs := &fakeCatalogStore{
+		named:         []string{"peers"},
+		entriesByName: map[string]map[string]string{"peers": {"react": ">=18"}},
+	}
+	uc := NewCatalogUseCase(NewDiscoveryService(fakeIndexer{infos: infos}), catalogs, &fakeManifestStore{}, fakeVersionResolver{})
+
+	listings, err := uc.RunList(context.Background(), CatalogListRequest{Names: []string{"peers"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(listings) != 1 || len(listings[0].Entries) != 1 || strings.Join(listings[0].Entries[0].Workspaces, ",") != ".,ui" {
+		t.Fatalf("unexpected listings: %#v", listings)
+	}
+}
+
+func TestCatalogUseCaseListUnknownCatalog(t *testing.T) {
+	catalogs := &fakeCatalogStore{named: []string{"legacy"}}
+	uc := NewCatalogUseCase(NewDiscoveryService(fakeIndexer{infos: fixtureInfos()}), catalogs, &fakeManifestStore{}, fakeVersionResolver{})
+
+	_, err := uc.RunList(context.Background(), CatalogListRequest{Names: []string{"react17"}, NamedOnly: true})
+	if !errors.Is(err, ErrCatalogNotFound) {
+		t.Fatalf("expected ErrCatalogNotFound, got %v", err)
+	}
+}
+
+func TestCatalogUseCasePruneRemovesUnreferencedEntries(t *testing.T) {
+	infos := fixtureInfos()
+	infos[0].DependencyVersions["react"] = "catalog:"
+	infos[1].DependencyVersions["clsx"] = "catalog:legacy"
+	catalogs := &fakeCatalogStore{
+		named: []string{"legacy", "react17"},
+		entriesByName: map[string]map[string]string{
+			"":        {"react": "^19.0.0", "zod": "^3.23.0", "lodash": "^4.17.21"},
+			"legacy":  {"clsx": "^1.2.1"},
+			"react17": {"react": "^17.0.2"},
+		},
+	}
+	uc := NewCatalogUseCase(NewDiscoveryService(fakeIndexer{infos: infos}), catalogs, &fakeManifestStore{}, fakeVersionResolver{})
+
+	pruned, err := uc.RunPrune(context.Background(), CatalogPruneRequest{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(pruned) != 2 || pruned[0].Name != "" || pruned[1].Name != "react17" {
+		t.Fatalf("unexpected pruned catalogs: %#v", pruned)
+	}
+	if got := strings.Join(catalogs.removedByName[""], ","); got != "lodash,zod" {
+		t.Fatalf("default catalog removals = %q", got)
+	}
+	if got := strings.Join(catalogs.removedByName["react17"], ","); got != "react" {
+		t.Fatalf("react17 catalog removals = %q", got)
+	}
+	if _, ok := catalogs.removedByName["legacy"]; ok {
+		t.Fatalf("legacy catalog should be left alone: %#v", catalogs.removedByName)
+	}
+}
+
+func TestCatalogUseCasePruneKeepsReferencesInLowerPriorityBuckets(t *testing.T) {
+	infos := fixtureInfos()
+	infos[1].DependencyVersions["react"] = "catalog:"
+	infos[1].DependencyBuckets = map[domain.PresetBucket]map[string]string{
+		domain.BucketDevDependencies:  {"react": "catalog:"},
+		domain.BucketPeerDependencies: {"react": "catalog:peers"},
+	}
+	catalogs := &fakeCatalogStore{
+		named: []string{"peers"},
+		entriesByName: map[string]map[string]string{
+			"":      {"react": "^19.0.0"},
+			"peers": {"react": ">=18"},
+		},
+	}
+	uc := NewCatalogUseCase(NewDiscoveryService(fakeIndexer{infos: infos}), catalogs, &fakeManifestStore{}, fakeVersionResolver{})
+
+	pruned, err := uc.RunPrune(context.Background(), CatalogPruneRequest{Names: []string{"peers"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(pruned) != 0 || len(catalogs.removedByName) != 0 {
+		t.Fatalf("expected nothing pruned, got %#v (removed %#v)", pruned, catalogs.removedByName)
+	}
+}
+
+func TestCatalogUseCasePruneNamedOnly(t *testing.T) {
+	catalogs := &fakeCatalogStore{
+		named: []string{"legacy"},
+		entriesByName: map[string]map[string]string{
+			"":       {"zod": "^3.23.0"},
+			"legacy": {"clsx": "^1.2.1"},
+		},
+	}
+	uc := NewCatalogUseCase(NewDiscoveryService(fakeIndexer{infos: fixtureInfos()}), catalogs, &fakeManifestStore{}, fakeVersionResolver{})
+
+	if _, err := uc.RunPrune(context.Background(), CatalogPruneRequest{NamedOnly: true}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(catalogs.removedByName) != 1 || strings.Join(catalogs.removedByName["legacy"], ",") != "clsx" {
+		t.Fatalf("unexpected removals: %#v", catalogs.removedByName)
+	}
+}
+
+func registryFixture() fakeVersionResolver {
+	return fakeVersionResolver{metadata: map[string]domain.PackageMetadata{
+		"react": {
+			Name:     "react",
+			DistTags: map[string]string{"latest": "19.1.0", "next": "19.2.0-rc.0"},
+			Versions: []string{"18.2.0", "18.3.1", "19.0.0", "19.1.0", "19.2.0-rc.0"},
+		},
+		"clsx": {
+			Name:     "clsx",
+			DistTags: map[string]string{"latest": "2.1.1"},
+			Versions: []string{"1.2.1", "2.0.0", "2.1.1"},
+		},
+		"zod": {
+			Name:     "zod",
+			DistTags: map[string]string{"latest": "3.23.8"},
+			Versions: []string{"3.22.0", "3.23.0", "3.23.8"},
+		},
+	}}
+}
+
+func TestCatalogUseCaseOutdatedComparesAgainstRegistry(t *testing.T) {
+	catalogs := &fakeCatalogStore{
+		named: []string{"legacy"},
+		entriesByName: map[string]map[string]string{
+			"":       {"react": "^18.2.0", "zod": "~3.23.8", "local": "workspace:*"},
+			"legacy": {"clsx": ">=1 <2"},
+		},
+	}
+	uc := NewCatalogUseCase(NewDiscoveryService(fakeIndexer{infos: fixtureInfos()}), catalogs, &fakeManifestStore{}, registryFixture())
+
+	outdated, err := uc.RunOutdated(context.Background(), CatalogOutdatedRequest{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []CatalogOutdatedEntry{
+		{Catalog: "", Package: "react", Range: "^18.2.0", Wanted: "18.3.1", Latest: "19.1.0"},
+		{Catalog: "legacy", Package: "clsx", Range: ">=1 <2", Wanted: "1.2.1", Latest: "2.1.1"},
+	}
+	if !reflect.DeepEqual(outdated, want) {
+		t.Fatalf("outdated = %#v, want %#v", outdated, want)
+	}
+}
+
+func TestCatalogUseCaseUpgradePreservesRangeOperator(t *testing.T) {
+	catalogs := &fakeCatalogStore{
+		named: []string{"legacy"},
+		entriesByName: map[string]map[string]string{
+			"":       {"react": "^18.2.0", "zod": "~3.22.0"},
+			"legacy": {"clsx": ">=1 <2"},
+		},
+	}
+	uc := NewCatalogUseCase(NewDiscoveryService(fakeIndexer{infos: fixtureInfos()}), catalogs, &fakeManifestStore{}, registryFixture())
+
+	upgrades, err := uc.RunUpgrade(context.Background(), CatalogUpgradeRequest{Level: domain.UpgradeMinor})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []CatalogUpgrade{
+		{Catalog: "", Package: "react", From: "^18.2.0", To: "^18.3.1"},
+		{Catalog: "", Package: "zod", From: "~3.22.0", To: "~3.23.8"},
+		{Catalog: "legacy", Package: "clsx", From: ">=1 <2", Skipped: "range is not a single version with an operator"},
+	}
+	if !reflect.DeepEqual(upgrades, want) {
+		t.Fatalf("upgrades = %#v, want %#v", upgrades, want)
+	}
+	if catalogs.name != "" || !catalogs.force || catalogs.entries["react"] != "^18.3.1" || catalogs.entries["zod"] != "~3.23.8" {
+		t.Fatalf("unexpected catalog write: name=%q force=%v entries=%#v", catalogs.name, catalogs.force, catalogs.entries)
+	}
+}
+
+func TestCatalogUseCaseUpgradeSelectedPackages(t *testing.T) {
+	catalogs := &fakeCatalogStore{
+		entriesByName: map[string]map[string]string{
+			"": {"react": "^18.2.0", "zod": "~3.22.0"},
+		},
+	}
+	uc := NewCatalogUseCase(NewDiscoveryService(fakeIndexer{infos: fixtureInfos()}), catalogs, &fakeManifestStore{}, registryFixture())
+
+	upgrades, err := uc.RunUpgrade(context.Background(), CatalogUpgradeRequest{Packages: []string{"react"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(upgrades) != 1 || upgrades[0].To != "^19.1.0" {
+		t.Fatalf("unexpected upgrades: %#v", upgrades)
+	}
+	if len(catalogs.entries) != 1 {
+		t.Fatalf("expected only react to be written, got %#v", catalogs.entries)
+	}
+
+	_, err = uc.RunUpgrade(context.Background(), CatalogUpgradeRequest{Packages: []string{"vite"}})
+	if !errors.Is(err, ErrPackageNotFound) {
+		t.Fatalf("expected ErrPackageNotFound, got %v", err)
+	}
+}
+
+func TestCatalogUseCaseSkipsUnpublishedPackages(t *testing.T) {
+	catalogs := &fakeCatalogStore{
+		entriesByName: map[string]map[string]string{
+			"": {"react": "^18.2.0", "@acme/private": "^1.0.0"},
+		},
+	}
+	uc := NewCatalogUseCase(NewDiscoveryService(fakeIndexer{infos: fixtureInfos()}), catalogs, &fakeManifestStore{}, registryFixture())
+
+	outdated, err := uc.RunOutdated(context.Background(), CatalogOutdatedRequest{})
+	if err != nil {
+		t.Fatalf("RunOutdated() error = %v", err)
+	}
+	wantOutdated := []CatalogOutdatedEntry{
+		{Package: "@acme/private", Range: "^1.0.0", Skipped: unpublishedReason},
+		{Package: "react", Range: "^18.2.0", Wanted: "18.3.1", Latest: "19.1.0"},
+	}
+	if !reflect.DeepEqual(outdated, wantOutdated) {
+		t.Fatalf("outdated = %#v, want %#v", outdated, wantOutdated)
+	}
+
+	upgrades, err := uc.RunUpgrade(context.Background(), CatalogUpgradeRequest{})
+	if err != nil {
+		t.Fatalf("RunUpgrade() error = %v", err)
+	}
+	wantUpgrades := []CatalogUpgrade{
+		{Package: "@acme/private", From: "^1.0.0", Skipped: unpublishedReason},
+		{Package: "react", From: "^18.2.0", To: "^19.1.0"},
+	}
+	if !reflect.DeepEqual(upgrades, wantUpgrades) {
+		t.Fatalf("upgrades = %#v, want %#v", upgrades, wantUpgrades)
+	}
+	if !reflect.DeepEqual(catalogs.entries, map[string]string{"react": "^19.1.0"}) {
+		t.Fatalf("catalog write = %#v", catalogs.entries)
+	}
+}
+
+// blockingVersionResolver fails one package and holds every other lookup
+// until its context is cancelled.
+type blockingVersionResolver struct {
+	failing string
+}
+
+func (blockingVersionResolver) LatestVersion(context.Context, string) (string, error) {
+	return "", errors.New("not used")
+}
+
+func (r blockingVersionResolver) PackageMetadata(ctx context.Context, packageName string) (domain.PackageMetadata, error) {
+	if packageName == r.failing {
+		return domain.PackageMetadata{}, errors.New("registry unavailable")
+	}
+	select {
+	case <-ctx.Done():
+		return domain.PackageMetadata{}, ctx.Err()
+	case <-time.After(5 * time.Second):
+		return domain.PackageMetadata{}, errors.New("lookup was not cancelled")
+	}
+}
+
+func TestCatalogUseCaseOutdatedCancelsLookupsOnError(t *testing.T) {
+	catalogs := &fakeCatalogStore{
+		entriesByName: map[string]map[string]string{
+			"": {"react": "^18.2.0", "react-dom": "^18.2.0", "zod": "^3.23.0"},
+		},
+	}
+	uc := NewCatalogUseCase(NewDiscoveryService(fakeIndexer{infos: fixtureInfos()}), catalogs, &fakeManifestStore{}, blockingVersionResolver{failing: "zod"})
+
+	_, err := uc.RunOutdated(context.Background(), CatalogOutdatedRequest{})
+	if err == nil || err.Error() != "registry unavailable" {
+		t.Fatalf("RunOutdated() error = %v, want the registry error", err)
+	}
+}
+
+type fakeRangeChooser struct {
+	choices map[string]string
+	asked   map[string][]string
+}
+
+func (f *fakeRangeChooser) ChooseRange(_ context.Context, pkg string, options []string, proposed string) (string, error) {
+	if f.asked == nil {
+		f.asked = map[string][]string{}
+	}
+	f.asked[pkg] = append(append([]string(nil), options...), "proposed="+proposed)
+	return f.choices[pkg], nil
+}
+
+func consolidateFixture() []domain.PackageInfo {
+	infos := fixtureInfos()
+	infos[0].DependencyVersions["zod"] = "^3.22.0"
+	infos[1].DependencyVersions["react"] = "^18.3.1"
+	infos[1].DependencyVersions["zod"] = "^3.23.8"
+	infos = append(infos, domain.PackageInfo{
+		Dir:                "apps/web",
+		DependencyVersions: map[string]string{"react": "catalog:", "clsx": "^2.1.1", "lodash": ">=4 <5"},
+	}, domain.PackageInfo{
+		Dir:                "apps/docs",
+		DependencyVersions: map[string]string{"lodash": "^4.17.21"},
+	})
+	return infos
+}
+
+func TestCatalogUseCaseConsolidateProposesHighestRange(t *testing.T) {
+	catalogs := &fakeCatalogStore{}
+	manifests := &fakeManifestStore{}
+	uc := NewCatalogUseCase(NewDiscoveryService(fakeIndexer{infos: consolidateFixture()}), catalogs, manifests, fakeVersionResolver{})
+
+	results, err := uc.RunConsolidate(context.Background(), CatalogConsolidateRequest{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []CatalogConsolidation{
+		{Package: "lodash", Range: "^4.17.21", Workspaces: map[string]string{"docs": "^4.17.21", "web": ">=4 <5"}},
+		{Package: "react", Range: "^19.0.0", Workspaces: map[string]string{".": "^19.0.0", "ui": "^18.3.1"}},
+		{Package: "zod", Range: "^3.23.8", Workspaces: map[string]string{".": "^3.22.0", "ui": "^3.23.8"}},
+	}
+	if !reflect.DeepEqual(results, want) {
+		t.Fatalf("results = %#v, want %#v", results, want)
+	}
+	if catalogs.name != "" || len(catalogs.entries) != 3 || catalogs.entries["zod"] != "^3.23.8" {
+		t.Fatalf("unexpected catalog write: %#v", catalogs.entries)
+	}
+
+	wantRewrites := []string{
+		"existing . dependencies catalog: react,zod",
+		"existing apps/docs dependencies catalog: lodash",
+		"existing packages/ui dependencies catalog: react,zod",
+		"existing apps/web dependencies catalog: lodash",
+	}
+	if !reflect.DeepEqual(manifests.sections, wantRewrites) {
+		t.Fatalf("rewrites = %#v, want %#v", manifests.sections, wantRewrites)
+	}
+}
+
+func TestCatalogUseCaseConsolidateLeavesPeerRangesAlone(t *testing.T) {
+	infos := fixtureInfos()
+	infos[0].DependencyBuckets = map[domain.PresetBucket]map[string]string{
+		domain.BucketDevDependencies: {"react": "^19.0.0"},
+	}
+	infos[1].DependencyBuckets = map[domain.PresetBucket]map[string]string{
+		domain.BucketDevDependencies:  {"react": "^18.3.1"},
+		domain.BucketPeerDependencies: {"react": ">=17"},
+	}
+	catalogs := &fakeCatalogStore{}
+	manifests := &fakeManifestStore{}
+	uc := NewCatalogUseCase(NewDiscoveryService(fakeIndexer{infos: infos}), catalogs, manifests, fakeVersionResolver{})
+
+	results, err := uc.RunConsolidate(context.Background(), CatalogConsolidateRequest{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []CatalogConsolidation{
+		{Package: "react", Range: "^19.0.0", Workspaces: map[string]string{".": "^19.0.0", "ui": "^18.3.1"}},
+	}
+	if !reflect.DeepEqual(results, want) {
+		t.Fatalf("results = %#v, want %#v", results, want)
+	}
+	wantRewrites := []string{
+		"existing . devDependencies catalog: react",
+		"existing packages/ui devDependencies catalog: react",
+	}
+	if !reflect.DeepEqual(manifests.sections, wantRewrites) || len(manifests.existing) != 0 {
+		t.Fatalf("rewrites = %#v (all sections %#v), want %#v", manifests.sections, manifests.existing, wantRewrites)
+	}
+}
+
+func TestCatalogUseCaseConsolidateUsesChooser(t *testing.T) {
+	catalogs := &fakeCatalogStore{}
+	manifests := &fakeManifestStore{}
+	chooser := &fakeRangeChooser{choices: map[string]string{"zod": "^3.22.0"}}
+	uc := NewCatalogUseCase(NewDiscoveryService(fakeIndexer{infos: consolidateFixture()}), catalogs, manifests, fakeVersionResolver{})
+
+	results, err := uc.RunConsolidate(context.Background(), CatalogConsolidateRequest{
+		Packages: []string{"zod", "react"},
+		Chooser:  chooser,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := strings.Join(chooser.asked["zod"], ","); got != "^3.22.0,^3.23.8,proposed=^3.23.8" {
+		t.Fatalf("zod options = %q", got)
+	}
+	if len(results) != 2 || results[0].Skipped != "skipped by user" || results[1].Range != "^3.22.0" {
+		t.Fatalf("unexpected results: %#v", results)
+	}
+	if len(catalogs.entries) != 1 || catalogs.entries["zod"] != "^3.22.0" {
+		t.Fatalf("unexpected catalog write: %#v", catalogs.entries)
+	}
+}
+
+func TestCatalogUseCaseConsolidateRejectsInvalidChosenRange(t *testing.T) {
+	catalogs := &fakeCatalogStore{}
+	manifests := &fakeManifestStore{}
+	chooser := &fakeRangeChooser{choices: map[string]string{"zod": "^3.22."}}
+	uc := NewCatalogUseCase(NewDiscoveryService(fakeIndexer{infos: consolidateFixture()}), catalogs, manifests, fakeVersionResolver{})
+
+	_, err := uc.RunConsolidate(context.Background(), CatalogConsolidateRequest{Packages: []string{"zod"}, Chooser: chooser})
+	if err == nil || !strings.Contains(err.Error(), "zod") {
+		t.Fatalf("RunConsolidate() error = %v, want invalid range error for zod", err)
+	}
+	if catalogs.entries != nil || len(manifests.sections) != 0 {
+		t.Fatalf("nothing should be written: entries=%#v rewrites=%#v", catalogs.entries, manifests.sections)
+	}
+}
+
+func TestCatalogUseCaseConsolidateRejectsConsistentPackage(t *testing.T) {
+	uc := NewCatalogUseCase(NewDiscoveryService(fakeIndexer{infos: consolidateFixture()}), &fakeCatalogStore{}, &fakeManifestStore{}, fakeVersionResolver{})
+
+	if _, err := uc.RunConsolidate(context.Background(), CatalogConsolidateRequest{Packages: []string{"clsx"}}); err == nil {
+		t.Fatal("expected error for package without differing ranges")
+	}
+	if _, err := uc.RunConsolidate(context.Background(), CatalogConsolidateRequest{Packages: []string{"vite"}}); !errors.Is(err, ErrPackageNotFound) {
+		t.Fatalf("expected ErrPackageNotFound, got %v", err)
+	}
+}
+
+func inlineFixture() []domain.PackageInfo {
+	infos := fixtureInfos()
+	infos[0].DependencyVersions["react"] = "catalog:"
+	infos[1].DependencyVersions["react"] = "catalog:"
+	infos[1].DependencyVersions["clsx"] = "catalog:legacy"
+	return infos
+}
+
+func TestCatalogUseCaseInlineCoversEverySection(t *testing.T) {
+	infos := fixtureInfos()
+	infos[1].DependencyBuckets = map[domain.PresetBucket]map[string]string{
+		domain.BucketDevDependencies:  {"react": "catalog:"},
+		domain.BucketPeerDependencies: {"react": "catalog:peers"},
+	}
+	catalogs := &fakeCatalogStore{
+		named: []string{"peers"},
+		entriesByName: map[string]map[string]string{
+			"":      {"react": "^19.0.0"},
+			"peers": {"react": ">=18"},
+		},
+	}
+	manifests := &fakeManifestStore{}
+	uc := NewCatalogUseCase(NewDiscoveryService(fakeIndexer{infos: infos}), catalogs, manifests, fakeVersionResolver{})
+
+	results, err := uc.RunInline(context.Background(), CatalogInlineRequest{Remove: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []CatalogInlining{
+		{Catalog: "", Package: "react", Version: "^19.0.0", Workspaces: []string{"ui"}, Removed: true},
+		{Catalog: "peers", Package: "react", Version: ">=18", Workspaces: []string{"ui"}, Removed: true},
+	}
+	if !reflect.DeepEqual(results, want) {
+		t.Fatalf("results = %#v, want %#v", results, want)
+	}
+	wantInlined := map[string]map[string]map[string]string{
+		"packages/ui": {"": {"react": "^19.0.0"}, "peers": {"react": ">=18"}},
+	}
+	if !reflect.DeepEqual(manifests.inlined, wantInlined) {
+		t.Fatalf("inlined = %#v, want %#v", manifests.inlined, wantInlined)
+	}
+	if strings.Join(catalogs.removedByName["peers"], ",") != "react" {
+		t.Fatalf("peers removals = %#v", catalogs.removedByName)
+	}
+}
+
+func TestCatalogUseCaseInlineReplacesReferences(t *testing.T) {
+	catalogs := &fakeCatalogStore{
+		named: []string{"legacy"},
+		entriesByName: map[string]map[string]string{
+			"":       {"react": "^19.0.0", "zod": "^3.23.8"},
+			"legacy": {"clsx": "^1.2.1"},
+		},
+	}
+	manifests := &fakeManifestStore{}
+	uc := NewCatalogUseCase(NewDiscoveryService(fakeIndexer{infos: inlineFixture()}), catalogs, manifests, fakeVersionResolver{})
+
+	results, err := uc.RunInline(context.Background(), CatalogInlineRequest{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []CatalogInlining{
+		{Catalog: "", Package: "react", Version: "^19.0.0", Workspaces: []string{".", "ui"}},
+		{Catalog: "legacy", Package: "clsx", Version: "^1.2.1", Workspaces: []string{"ui"}},
+	}
+	if !reflect.DeepEqual(results, want) {
+		t.Fatalf("results = %#v, want %#v", results, want)
+	}
+	wantInlined := map[string]map[string]map[string]string{
+		".":           {"": {"react": "^19.0.0"}},
+		"packages/ui": {"": {"react": "^19.0.0"}, "legacy": {"clsx": "^1.2.1"}},
+	}
+	if !reflect.DeepEqual(manifests.inlined, wantInlined) {
+		t.Fatalf("inlined = %#v, want %#v", manifests.inlined, wantInlined)
+	}
+	if len(catalogs.removedByName) != 0 {
+		t.Fatalf("catalog entries should be kept without Remove: %#v", catalogs.removedByName)
+	}
+}
+
+func TestCatalogUseCaseInlineSelectedPackageAndRemove(t *testing.T) {
+	catalogs := &fakeCatalogStore{
+		named: []string{"legacy"},
+		entriesByName: map[string]map[string]string{
+			"":       {"react": "^19.0.0"},
+			"legacy": {"clsx": "^1.2.1"},
+		},
+	}
+	manifests := &fakeManifestStore{}
+	uc := NewCatalogUseCase(NewDiscoveryService(fakeIndexer{infos: inlineFixture()}), catalogs, manifests, fakeVersionResolver{})
+
+	results, err := uc.RunInline(context.Background(), CatalogInlineRequest{Packages: []string{"clsx"}, Remove: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(results) != 1 || !results[0].Removed || results[0].Catalog != "legacy" {
+		t.Fatalf("unexpected results: %#v", results)
+	}
+	if len(manifests.inlined) != 1 || manifests.inlined["packages/ui"]["legacy"]["clsx"] != "^1.2.1" {
+		t.Fatalf("unexpected inlined manifests: %#v", manifests.inlined)
+	}
+	if !reflect.DeepEqual(catalogs.removedByName, map[string][]string{"legacy": {"clsx"}}) {
+		t.Fatalf("unexpected removals: %#v", catalogs.removedByName)
+	}
+}
+
+func TestCatalogUseCaseInlineFailsOnDanglingReference(t *testing.T) {
+	catalogs := &fakeCatalogStore{entriesByName: map[string]map[string]string{"": {}}}
+	manifests := &fakeManifestStore{}
+	uc := NewCatalogUseCase(NewDiscoveryService(fakeIndexer{infos: inlineFixture()}), catalogs, manifests, fakeVersionResolver{})
+
+	_, err := uc.RunInline(context.Background(), CatalogInlineRequest{})
+	if !errors.Is(err, ErrPackageNotFound) && !errors.Is(err, ErrCatalogNotFound) {
+		t.Fatalf("expected a not-found error, got %v", err)
+	}
+	if len(manifests.inlined) != 0 {
+		t.Fatalf("no manifest should be written: %#v", manifests.inlined)
+	}
+}
+
+// managerCatalogStore keeps separate catalogs per package manager.
+type managerCatalogStore struct {
+	catalogs map[domain.PackageManager]map[string]map[string]string
+	calls    []string
+}
+
+func (f *managerCatalogStore) UpsertCatalogEntries(_ context.Context, manager domain.PackageManager, name string, entries map[string]string, _ bool) error {
+	f.calls = append(f.calls, fmt.Sprintf("upsert %s/%s %s", manager, name, strings.Join(sortedPackageNames(entries), ",")))
+	if f.catalogs[manager] == nil {
+		f.catalogs[manager] = map[string]map[string]string{}
+	}
+	if f.catalogs[manager][name] == nil {
+		f.catalogs[manager][name] = map[string]string{}
+	}
+	for pkg, version := range entries {
+		f.catalogs[manager][name][pkg] = version
+	}
+	return nil
+}
+
+func (f *managerCatalogStore) RemoveCatalogEntries(_ context.Context, manager domain.PackageManager, name string, packages []string) error {
+	f.calls = append(f.calls, fmt.Sprintf("remove %s/%s %s", manager, name, strings.Join(packages, ",")))
+	for _, pkg := range packages {
+		delete(f.catalogs[manager][name], pkg)
+	}
+	return nil
+}
+
+func (f *managerCatalogStore) RenameCatalog(_ context.Context, manager domain.PackageManager, oldName string, newName string) error {
+	f.calls = append(f.calls, fmt.Sprintf("rename %s/%s %s", manager, oldName, newName))
+	f.catalogs[manager][newName] = f.catalogs[manager][oldName]
+	delete(f.catalogs[manager], oldName)
+	return nil
+}
+
+func (f *managerCatalogStore) DeleteCatalog(_ context.Context, manager domain.PackageManager, name string) error {
+	f.calls = append(f.calls, fmt.Sprintf("delete %s/%s", manager, name))
+	delete(f.catalogs[manager], name)
+	return nil
+}
+
+func (f *managerCatalogStore) NamedCatalogs(_ context.Context, manager domain.PackageManager) ([]string, error) {
+	names := []string{}
+	for name := range f.catalogs[manager] {
+		if name != "" {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+func (f *managerCatalogStore) CatalogPackageNames(_ context.Context, manager domain.PackageManager, name string) ([]string, error) {
+	return sortedPackageNames(f.catalogs[manager][name]), nil
+}
+
+func (f *managerCatalogStore) CatalogEntries(_ context.Context, manager domain.PackageManager, name string) (map[string]string, error) {
+	out := map[string]string{}
+	for pkg, version := range f.catalogs[manager][name] {
+		out[pkg] = version
+	}
+	return out, nil
+}
+
+func TestCatalogUseCaseMigrateMovesAllCatalogs(t *testing.T) {
+	store := &managerCatalogStore{catalogs: map[domain.PackageManager]map[string]map[string]string{
+		domain.ManagerPNPM: {
+			"":        {"react": "^19.0.0", "zod": "^3.23.8"},
+			"react17": {"react": "^17.0.2"},
+		},
+	}}
+	uc := NewCatalogUseCase(NewDiscoveryService(fakeIndexer{infos: fixtureInfos()}), store, &fakeManifestStore{}, fakeVersionResolver{})
+
+	migration, err := uc.RunMigrate(context.Background(), CatalogMigrateRequest{To: domain.ManagerBun})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if migration.From != domain.ManagerPNPM || migration.To != domain.ManagerBun || len(migration.Catalogs) != 2 {
+		t.Fatalf("unexpected migration: %#v", migration)
+	}
+
+	wantCalls := []string{
+		"upsert bun/ react,zod",
+		"upsert bun/react17 react",
+		"remove pnpm/ react,zod",
+		"remove pnpm/react17 react",
+	}
+	if !reflect.DeepEqual(store.calls, wantCalls) {
+		t.Fatalf("calls = %#v, want %#v", store.calls, wantCalls)
+	}
+	if store.catalogs[domain.ManagerBun]["react17"]["react"] != "^17.0.2" {
+		t.Fatalf("bun catalogs = %#v", store.catalogs[domain.ManagerBun])
+	}
+}
+
+func TestCatalogUseCaseMigrateRefusesConflicts(t *testing.T) {
+	store := &managerCatalogStore{catalogs: map[domain.PackageManager]map[string]map[string]string{
+		domain.ManagerPNPM: {"": {"react": "^19.0.0"}},
+		domain.ManagerBun:  {"": {"react": "^18.3.1"}},
+	}}
+	uc := NewCatalogUseCase(NewDiscoveryService(fakeIndexer{infos: fixtureInfos()}), store, &fakeManifestStore{}, fakeVersionResolver{})
+
+	_, err := uc.RunMigrate(context.Background(), CatalogMigrateRequest{To: domain.ManagerBun})
+	if !errors.Is(err, ErrCatalogConflict) {
+		t.Fatalf("expected ErrCatalogConflict, got %v", err)
+	}
+	if len(store.calls) != 0 {
+		t.Fatalf("no catalog should be written on conflict: %#v", store.calls)
+	}
+
+	if _, err := uc.RunMigrate(context.Background(), CatalogMigrateRequest{To: domain.ManagerBun, Force: true}); err != nil {
+		t.Fatalf("unexpected error with force: %v", err)
+	}
+	if store.catalogs[domain.ManagerBun][""]["react"] != "^19.0.0" {
+		t.Fatalf("force should overwrite the target entry: %#v", store.catalogs[domain.ManagerBun])
+	}
+}
+
+func TestCatalogUseCaseMigrateRejectsNPM(t *testing.T) {
+	store := &managerCatalogStore{catalogs: map[domain.PackageManager]map[string]map[string]string{}}
+	uc := NewCatalogUseCase(NewDiscoveryService(fakeIndexer{infos: fixtureInfos()}), store, &fakeManifestStore{}, fakeVersionResolver{})
+
+	if _, err := uc.RunMigrate(context.Background(), CatalogMigrateRequest{To: domain.ManagerNPM}); !errors.Is(err, ErrCatalogUnsupported) {
+		t.Fatalf("expected ErrCatalogUnsupported, got %v", err)
+	}
+	if _, err := uc.RunMigrate(context.Background(), CatalogMigrateRequest{To: domain.ManagerPNPM}); err == nil {
+		t.Fatal("expected error when migrating to the current manager")
+	}
+}
+
+func TestCatalogUseCaseSyncAllReportsAmbiguousPackages(t *testing.T) {
+	catalogs := &fakeCatalogStore{
+		named: []string{"react17", "tooling"},
+		catalogByName: map[string][]string{
+			"":        {"react", "zod"},
+			"react17": {"react", "react-dom"},
+			"tooling": {"typescript"},
+		},
+	}
+	manifests := &fakeManifestStore{}
+	uc := NewCatalogUseCase(NewDiscoveryService(fakeIndexer{infos: fixtureInfos()}), catalogs, manifests, fakeVersionResolver{})
+
+	result, err := uc.RunSync(context.Background(), CatalogSyncRequest{All: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Join(result.Catalogs, ",") != ",react17,tooling" {
+		t.Fatalf("catalogs = %#v", result.Catalogs)
+	}
+	wantAmbiguous := []CatalogAmbiguity{{Package: "react", Catalogs: []string{"", "react17"}}}
+	if !reflect.DeepEqual(result.Ambiguous, wantAmbiguous) {
+		t.Fatalf("ambiguous = %#v, want %#v", result.Ambiguous, wantAmbiguous)
+	}
+	wantSync := []manifestRewriteCall{
+		{dir: "packages/ui", name: "", packages: []string{"zod"}},
+		{dir: "packages/ui", name: "react17", packages: []string{"react-dom"}},
+		{dir: "packages/ui", name: "tooling", packages: []string{"typescript"}},
+	}
+	if !reflect.DeepEqual(manifests.sync, wantSync) {
+		t.Fatalf("sync calls = %#v, want %#v", manifests.sync, wantSync)
+	}
+}
+
+func TestCatalogUseCaseSyncDefaultKeepsPackagesSharedWithNamedCatalogs(t *testing.T) {
+	infos := append(fixtureInfos(), domain.PackageInfo{
+		Dir: "apps/legacy",
+		DependencyBuckets: map[domain.PresetBucket]map[string]string{
+			domain.BucketDependencies:     {"react": "catalog:react17", "zod": "^3.22.0"},
+			domain.BucketPeerDependencies: {"react": "^17.0.0"},
+		},
+	})
+	catalogs := &fakeCatalogStore{
+		named: []string{"react17"},
+		catalogByName: map[string][]string{
+			"":        {"react", "zod"},
+			"react17": {"react"},
+		},
+	}
+	manifests := &fakeManifestStore{}
+	uc := NewCatalogUseCase(NewDiscoveryService(fakeIndexer{infos: infos}), catalogs, manifests, fakeVersionResolver{})
+
+	result, err := uc.RunSync(context.Background(), CatalogSyncRequest{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Ambiguous) != 0 {
+		t.Fatalf("ambiguous = %#v, want none for a default-only sync", result.Ambiguous)
+	}
+	wantSync := []manifestRewriteCall{
+		{dir: "apps/legacy", name: "", packages: []string{"zod"}},
+		{dir: "packages/ui", name: "", packages: []string{"react", "zod"}},
+	}
+	if !reflect.DeepEqual(manifests.sync, wantSync) {
+		t.Fatalf("sync calls = %#v, want %#v", manifests.sync, wantSync)
+	}
+}
+
+func TestCatalogUseCaseSyncNamedCatalog(t *testing.T) {
+	catalogs := &fakeCatalogStore{
+		named:         []string{"react17"},
+		catalogByName: map[string][]string{"": {"zod"}, "react17": {"react"}},
+	}
+	manifests := &fakeManifestStore{}
+	uc := NewCatalogUseCase(NewDiscoveryService(fakeIndexer{infos: fixtureInfos()}), catalogs, manifests, fakeVersionResolver{})
+
+	if _, err := uc.RunSync(context.Background(), CatalogSyncRequest{Names: []string{"react17"}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(manifests.sync) != 1 || manifests.sync[0].name != "react17" {
+		t.Fatalf("sync calls = %#v", manifests.sync)
+	}
+
+	_, err := uc.RunSync(context.Background(), CatalogSyncRequest{Names: []string{"missing"}})
+	if !errors.Is(err, ErrCatalogNotFound) {
+		t.Fatalf("expected ErrCatalogNotFound, got %v", err)
+	}
+}
+
+func namedCatalogFixture() *managerCatalogStore {
+	return &managerCatalogStore{catalogs: map[domain.PackageManager]map[string]map[string]string{
+		domain.ManagerPNPM: {
+			"":       {"react": "^19.0.0"},
+			"legacy": {"clsx": "^1.2.1"},
+			"tools":  {"typescript": "^5.6.0"},
+		},
+	}}
+}
+
+func TestCatalogUseCaseRenameRewritesReferences(t *testing.T) {
+	store := namedCatalogFixture()
+	manifests := &fakeManifestStore{}
+	uc := NewCatalogUseCase(NewDiscoveryService(fakeIndexer{infos: inlineFixture()}), store, manifests, fakeVersionResolver{})
+
+	rename, err := uc.RunRename(context.Background(), CatalogRenameRequest{From: "legacy", To: "clsx1"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := CatalogRename{From: "legacy", To: "clsx1", Workspaces: []string{"ui"}}
+	if !reflect.DeepEqual(rename, want) {
+		t.Fatalf("rename = %#v, want %#v", rename, want)
+	}
+	if !reflect.DeepEqual(store.calls, []string{"rename pnpm/legacy clsx1"}) {
+		t.Fatalf("calls = %#v", store.calls)
+	}
+	if !reflect.DeepEqual(manifests.renamed, []string{"packages/ui legacy->clsx1"}) {
+		t.Fatalf("renamed = %#v", manifests.renamed)
+	}
+
+	if _, err := uc.RunRename(context.Background(), CatalogRenameRequest{From: "clsx1", To: "tools"}); !errors.Is(err, ErrCatalogConflict) {
+		t.Fatalf("expected ErrCatalogConflict, got %v", err)
+	}
+	if _, err := uc.RunRename(context.Background(), CatalogRenameRequest{From: "legacy", To: "other"}); !errors.Is(err, ErrCatalogNotFound) {
+		t.Fatalf("expected ErrCatalogNotFound, got %v", err)
+	}
+}
+
+func TestCatalogUseCaseCopyDuplicatesEntries(t *testing.T) {
+	store := namedCatalogFixture()
+	uc := NewCatalogUseCase(NewDiscoveryService(fakeIndexer{infos: inlineFixture()}), store, &fakeManifestStore{}, fakeVersionResolver{})
+
+	copied, err := uc.RunCopy(context.Background(), CatalogCopyRequest{From: "tools", To: "tools-next"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if copied != (CatalogCopy{From: "tools", To: "tools-next", Entries: 1}) {
+		t.Fatalf("copy = %#v", copied)
+	}
+	if store.catalogs[domain.ManagerPNPM]["tools-next"]["typescript"] != "^5.6.0" || len(store.catalogs[domain.ManagerPNPM]["tools"]) != 1 {
+		t.Fatalf("catalogs = %#v", store.catalogs[domain.ManagerPNPM])
+	}
+}
+
+func TestCatalogUseCaseDeleteRefusesReferencedCatalog(t *testing.T) {
+	store := namedCatalogFixture()
+	manifests := &fakeManifestStore{}
+	uc := NewCatalogUseCase(NewDiscoveryService(fakeIndexer{infos: inlineFixture()}), store, manifests, fakeVersionResolver{})
+
+	_, err := uc.RunDelete(context.Background(), CatalogDeleteRequest{Name: "legacy"})
+	if !errors.Is(err, ErrCatalogInUse) {
+		t.Fatalf("expected ErrCatalogInUse, got %v", err)
+	}
+	if len(store.calls) != 0 || len(manifests.inlined) != 0 {
+		t.Fatalf("nothing should be written: calls=%#v inlined=%#v", store.calls, manifests.inlined)
+	}
+
+	deletion, err := uc.RunDelete(context.Background(), CatalogDeleteRequest{Name: "legacy", Force: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := CatalogDeletion{Name: "legacy", Entries: 1, Workspaces: []string{"ui"}}
+	if !reflect.DeepEqual(deletion, want) {
+		t.Fatalf("deletion = %#v, want %#v", deletion, want)
+	}
+	wantInlined := map[string]map[string]map[string]string{"packages/ui": {"legacy": {"clsx": "^1.2.1"}}}
+	if !reflect.DeepEqual(manifests.inlined, wantInlined) {
+		t.Fatalf("inlined = %#v, want %#v", manifests.inlined, wantInlined)
+	}
+	if !reflect.DeepEqual(store.calls, []string{"delete pnpm/legacy"}) {
+		t.Fatalf("calls = %#v", store.calls)
+	}
+}
+
+func TestCatalogUseCaseDeleteUnreferencedCatalog(t *testing.T) {
+	store := namedCatalogFixture()
+	manifests := &fakeManifestStore{}
+	uc := NewCatalogUseCase(NewDiscoveryService(fakeIndexer{infos: inlineFixture()}), store, manifests, fakeVersionResolver{})
+
+	deletion, err := uc.RunDelete(context.Background(), CatalogDeleteRequest{Name: "tools"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if deletion.Entries != 1 || len(deletion.Workspaces) != 0 || len(manifests.inlined) != 0 {
+		t.Fatalf("unexpected deletion: %#v inlined=%#v", deletion, manifests.inlined)
+	}
+}
+
+func TestCatalogUseCaseDeleteEmptyCatalog(t *testing.T) {
+	store := namedCatalogFixture()
+	store.catalogs[domain.ManagerPNPM]["empty"] = map[string]string{}
+	uc := NewCatalogUseCase(NewDiscoveryService(fakeIndexer{infos: inlineFixture()}), store, &fakeManifestStore{}, fakeVersionResolver{})
+
+	deletion, err := uc.RunDelete(context.Background(), CatalogDeleteRequest{Name: "empty"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if deletion.Entries != 0 || !reflect.DeepEqual(store.calls, []string{"delete pnpm/empty"}) {
+		t.Fatalf("deletion = %#v calls = %#v", deletion, store.calls)
+	}
+	if _, ok := store.catalogs[domain.ManagerPNPM]["empty"]; ok {
+		t.Fatalf("catalog not deleted: %#v", store.catalogs[domain.ManagerPNPM])
+	}
+}
+
+func TestCatalogUseCaseDeleteRefusesDanglingReferences(t *testing.T) {
+	store := namedCatalogFixture()
+	infos := inlineFixture()
+	infos[0].DependencyVersions["tailwind-merge"] = "catalog:legacy"
+	manifests := &fakeManifestStore{}
+	uc := NewCatalogUseCase(NewDiscoveryService(fakeIndexer{infos: infos}), store, manifests, fakeVersionResolver{})
+
+	_, err := uc.RunDelete(context.Background(), CatalogDeleteRequest{Name: "legacy", Force: true})
+	if !errors.Is(err, ErrPackageNotFound) {
+		t.Fatalf("expected ErrPackageNotFound, got %v", err)
+	}
+	if len(store.calls) != 0 || len(manifests.inlined) != 0 {
+		t.Fatalf("nothing should be written: calls=%#v inlined=%#v", store.calls, manifests.inlined)
+	}
+}
+
+// shadowedLegacyFixture references the legacy catalog only from sections that
+// lose the DependencyVersions merge.
+func shadowedLegacyFixture() []domain.PackageInfo {
+	infos := fixtureInfos()
+	infos[0].DependencyBuckets = map[domain.PresetBucket]map[string]string{
+		domain.BucketDependencies:         {"clsx": "^2.1.1"},
+		domain.BucketOptionalDependencies: {"clsx": "catalog:legacy"},
+	}
+	infos[1].DependencyBuckets = map[domain.PresetBucket]map[string]string{
+		domain.BucketDevDependencies:  {"clsx": "^2.1.1"},
+		domain.BucketPeerDependencies: {"clsx": "catalog:legacy"},
+	}
+	return infos
+}
+
+func TestCatalogUseCaseDeleteSeesReferencesInEverySection(t *testing.T) {
+	store := namedCatalogFixture()
+	manifests := &fakeManifestStore{}
+	uc := NewCatalogUseCase(NewDiscoveryService(fakeIndexer{infos: shadowedLegacyFixture()}), store, manifests, fakeVersionResolver{})
+
+	if _, err := uc.RunDelete(context.Background(), CatalogDeleteRequest{Name: "legacy"}); !errors.Is(err, ErrCatalogInUse) {
+		t.Fatalf("expected ErrCatalogInUse, got %v", err)
+	}
+
+	deletion, err := uc.RunDelete(context.Background(), CatalogDeleteRequest{Name: "legacy", Force: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(deletion.Workspaces, []string{".", "ui"}) {
+		t.Fatalf("workspaces = %#v", deletion.Workspaces)
+	}
+	if _, ok := manifests.inlined["."]; !ok {
+		t.Fatalf("root references not inlined: %#v", manifests.inlined)
+	}
+	if _, ok := manifests.inlined["packages/ui"]; !ok {
+		t.Fatalf("ui references not inlined: %#v", manifests.inlined)
+	}
+}
+
+func TestCatalogUseCaseRenameSeesReferencesInEverySection(t *testing.T) {
+	store := namedCatalogFixture()
+	manifests := &fakeManifestStore{}
+	uc := NewCatalogUseCase(NewDiscoveryService(fakeIndexer{infos: shadowedLegacyFixture()}), store, manifests, fakeVersionResolver{})
+
+	rename, err := uc.RunRename(context.Background(), CatalogRenameRequest{From: "legacy", To: "clsx1"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(rename.Workspaces, []string{".", "ui"}) {
+		t.Fatalf("workspaces = %#v", rename.Workspaces)
+	}
+	want := []string{". legacy->clsx1", "packages/ui legacy->clsx1"}
+	if !reflect.DeepEqual(manifests.renamed, want) {
+		t.Fatalf("renamed = %#v, want %#v", manifests.renamed, want)
+	}
+}
+
+func TestCatalogUseCaseCheckReportsViolations(t *testing.T) {
+	infos := fixtureInfos()
+	infos[0].DependencyBuckets = map[domain.PresetBucket]map[string]string{
+		domain.BucketDependencies:    {"react": "catalog:"},
+		domain.BucketDevDependencies: {"zod": "^3.22.0", "vitest": "^2.0.0"},
+	}
+	infos[1].DependencyBuckets = map[domain.PresetBucket]map[string]string{
+		domain.BucketDependencies:     {"clsx": "catalog:legacy", "react": "catalog:react17"},
+		domain.BucketPeerDependencies: {"react-dom": "catalog:"},
+	}
+	catalogs := &fakeCatalogStore{
+		named: []string{"react17"},
+		entriesByName: map[string]map[string]string{
+			"":        {"react": "^19.0.0", "zod": "^3.23.8"},
+			"react17": {"react": "^17.0.2"},
+		},
+	}
+	uc := NewCatalogUseCase(NewDiscoveryService(fakeIndexer{infos: infos}), catalogs, &fakeManifestStore{}, fakeVersionResolver{})
+
+	violations, err := uc.RunCheck(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []CatalogViolation{
+		{Kind: ViolationHardcodedRange, Workspace: ".", Bucket: domain.BucketDevDependencies, Package: "zod", Spec: "^3.22.0", Catalogs: []string{""}},
+		{Kind: ViolationUnknownCatalog, Workspace: "ui", Bucket: domain.BucketDependencies, Package: "clsx", Spec: "catalog:legacy", Catalogs: []string{"legacy"}},
+		{Kind: ViolationMissingEntry, Workspace: "ui", Bucket: domain.BucketPeerDependencies, Package: "react-dom", Spec: "catalog:", Catalogs: []string{""}},
+	}
+	if !reflect.DeepEqual(violations, want) {
+		t.Fatalf("violations = %#v, want %#v", violations, want)
+	}
+}
+
+func TestCatalogUseCaseCheckIgnoresNonRangeSpecs(t *testing.T) {
+	infos := fixtureInfos()
+	infos[0].DependencyBuckets = map[domain.PresetBucket]map[string]string{
+		domain.BucketDependencies:    {"@acme/ui": "workspace:*", "react": "npm:@preact/compat@^17.0.0"},
+		domain.BucketDevDependencies: {"zod": "file:../vendor/zod", "tailwind-merge": "link:../tailwind-merge"},
+	}
+	catalogs := &fakeCatalogStore{entriesByName: map[string]map[string]string{
+		"": {"@acme/ui": "^1.0.0", "react": "^19.0.0", "zod": "^3.23.8", "tailwind-merge": "^2.5.0"},
+	}}
+	uc := NewCatalogUseCase(NewDiscoveryService(fakeIndexer{infos: infos}), catalogs, &fakeManifestStore{}, fakeVersionResolver{})
+
+	violations, err := uc.RunCheck(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(violations) != 0 {
+		t.Fatalf("violations = %#v, want none", violations)
+	}
+}
+
+func TestCatalogUseCaseCheckClean(t *testing.T) {
+	infos := fixtureInfos()
+	infos[0].DependencyBuckets = map[domain.PresetBucket]map[string]string{
+		domain.BucketDependencies: {"react": "catalog:"},
+	}
+	catalogs := &fakeCatalogStore{entriesByName: map[string]map[string]string{"": {"react": "^19.0.0"}}}
+	uc := NewCatalogUseCase(NewDiscoveryService(fakeIndexer{infos: infos}), catalogs, &fakeManifestStore{}, fakeVersionResolver{})
+
+	violations, err := uc.RunCheck(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(violations) != 0 {
+		t.Fatalf("violations = %#v, want none", violations)
+	}
+}
@@ -0,0 +1,60 @@
+package app
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+
+	"ordo/internal/domain"
+)
+
+type invocationDirKey struct{}
+
+type managerOverrideKey struct{}
+
+type dryRunKey struct{}
+
+// WithInvocationDir records the directory ordo was invoked from, relative to the
+// project root, so bare targets default to the workspace containing it.
+func WithInvocationDir(ctx context.Context, dir string) context.Context {
+	return context.WithValue(ctx, invocationDirKey{}, filepath.ToSlash(filepath.Clean(dir)))
+}
+
+func invocationDir(ctx context.Context) string {
+	if ctx == nil {
+		return "."
+	}
+	dir, ok := ctx.Value(invocationDirKey{}).(string)
+	if !ok || dir == "" || dir == ".." || strings.HasPrefix(dir, "../") {
+		return "."
+	}
+	return dir
+}
+
+// WithManagerOverride forces the package manager used by discovery, bypassing
+// detection.
+func WithManagerOverride(ctx context.Context, manager domain.PackageManager) context.Context {
+	return context.WithValue(ctx, managerOverrideKey{}, manager)
+}
+
+func managerOverride(ctx context.Context) domain.PackageManager {
+	if ctx == nil {
+		return ""
+	}
+	manager, _ := ctx.Value(managerOverrideKey{}).(domain.PackageManager)
+	return manager
+}
+
+// WithDryRun marks the invocation as a dry run: commands are reported instead of
+// executed.
+func WithDryRun(ctx context.Context) context.Context {
+	return context.WithValue(ctx, dryRunKey{}, true)
+}
+
+func IsDryRun(ctx context.Context) bool {
+	if ctx == nil {
+		return false
+	}
+	dryRun, _ := ctx.Value(dryRunKey{}).(bool)
+	return dryRun
+}
@@ -2,20 +2,42 @@ package app
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"path/filepath"
 	"sort"
 	"strings"
 
+	"ordo/internal/config"
 	"ordo/internal/domain"
 	"ordo/internal/ports"
 )
 
 type DiscoveryService struct {
-	indexer ports.WorkspaceIndexer
+	indexer    ports.WorkspaceIndexer
+	config     presetConfigService
+	projectDir string
+	notifier   ports.Notifier
 }
 
 func NewDiscoveryService(indexer ports.WorkspaceIndexer) DiscoveryService {
-	return DiscoveryService{indexer: indexer}
+	return NewDiscoveryServiceWithConfig(indexer, nil, "", nil)
+}
+
+// NewDiscoveryServiceWithConfig also consults the project and user ordo.json
+// defaults when detecting the package manager, and reports warnings to notifier.
+func NewDiscoveryServiceWithConfig(
+	indexer ports.WorkspaceIndexer,
+	configStore ports.ConfigStore,
+	projectDir string,
+	notifier ports.Notifier,
+) DiscoveryService {
+	return DiscoveryService{
+		indexer:    indexer,
+		config:     newPresetConfigService(configStore),
+		projectDir: projectDir,
+		notifier:   notifier,
+	}
 }
 
 func (d DiscoveryService) Snapshot(ctx context.Context) (Snapshot, error) {
@@ -35,6 +57,13 @@ func (d DiscoveryService) Snapshot(ctx context.Context) (Snapshot, error) {
 		if info.DependencyVersions == nil {
 			info.DependencyVersions = map[string]string{}
 		}
+		if info.DependencyBuckets == nil {
+			// Indexers that do not split dependency sections report the
+			// merged map as dependencies.
+			info.DependencyBuckets = map[domain.PresetBucket]map[string]string{
+				domain.BucketDependencies: info.DependencyVersions,
+			}
+		}
 		if info.Lockfiles == nil {
 			info.Lockfiles = map[string]bool{}
 		}
@@ -55,23 +84,114 @@ func (d DiscoveryService) Snapshot(ctx context.Context) (Snapshot, error) {
 		byWorkspace[item.WorkspaceKey] = item
 	}
 
-	for _, name := range []string{"bun.lockb", "bun.lock", "pnpm-lock.yaml", "yarn.lock", "package-lock.json", "npm-shrinkwrap.json"} {
+	for _, name := range domain.Lockfiles() {
 		if root.Lockfiles[name] {
 			lockfiles[name] = true
 		}
 	}
 
+	resolution, err := d.resolveManager(ctx, root, lockfiles)
+	if err != nil {
+		return Snapshot{}, err
+	}
+	ReportFrom(ctx).setManager(resolution.Manager, resolution.Version)
+
 	return Snapshot{
 		Root:        root,
 		ByWorkspace: byWorkspace,
-		Manager:     domain.DetectManager(lockfiles),
+		Manager:     resolution.Manager,
+		Current:     currentWorkspace(byWorkspace, invocationDir(ctx)),
 	}, nil
 }
 
+func (d DiscoveryService) resolveManager(ctx context.Context, root domain.PackageInfo, lockfiles map[string]bool) (domain.ManagerResolution, error) {
+	hints := domain.ManagerHints{
+		Override:            managerOverride(ctx),
+		PackageManagerField: root.PackageManager,
+		Lockfiles:           lockfiles,
+	}
+	if d.config.configStore != nil {
+		project, err := d.config.loadPath(config.ProjectConfigPath(d.projectDir))
+		if err != nil && !errors.Is(err, ErrConfigNotFound) {
+			return domain.ManagerResolution{}, err
+		}
+		hints.ProjectDefault = project.DefaultPackageManager
+
+		user, err := d.config.load()
+		if err != nil && !errors.Is(err, ErrConfigNotFound) {
+			return domain.ManagerResolution{}, err
+		}
+		hints.UserDefault = user.DefaultPackageManager
+	}
+
+	resolution, err := domain.ResolveManager(hints)
+	if err != nil {
+		return domain.ManagerResolution{}, err
+	}
+
+	if len(resolution.LockfileManagers) > 1 {
+		if resolution.Source == domain.SourceLockfile {
+			return domain.ManagerResolution{}, AmbiguousManagerError{Lockfiles: presentLockfiles(lockfiles)}
+		}
+		d.warn(fmt.Sprintf("lockfiles from multiple package managers found (%s); using %s from %s",
+			strings.Join(presentLockfiles(lockfiles), ", "), resolution.Manager, resolution.Source))
+	}
+	return resolution, nil
+}
+
+func (d DiscoveryService) warn(message string) {
+	if d.notifier == nil {
+		return
+	}
+	d.notifier.Warn(message)
+}
+
+func presentLockfiles(lockfiles map[string]bool) []string {
+	items := make([]string, 0, len(lockfiles))
+	for _, name := range domain.Lockfiles() {
+		if lockfiles[name] {
+			items = append(items, name)
+		}
+	}
+	return items
+}
+
 type Snapshot struct {
 	Root        domain.PackageInfo
 	ByWorkspace map[string]domain.PackageInfo
 	Manager     domain.PackageManager
+	// Current is the key of the workspace ordo was invoked from, or empty at the root.
+	Current string
+}
+
+// Workspace looks up a workspace by key, then by package name.
+func (s Snapshot) Workspace(ref string) (domain.PackageInfo, bool) {
+	if pkg, ok := s.ByWorkspace[ref]; ok {
+		return pkg, true
+	}
+	for _, pkg := range s.ByWorkspace {
+		if pkg.Name != "" && pkg.Name == ref {
+			return pkg, true
+		}
+	}
+	return domain.PackageInfo{}, false
+}
+
+// currentWorkspace returns the key of the deepest workspace containing dir.
+func currentWorkspace(workspaces map[string]domain.PackageInfo, dir string) string {
+	best := ""
+	bestLen := 0
+	for key, pkg := range workspaces {
+		wsDir := filepath.ToSlash(pkg.Dir)
+		if dir != wsDir && !strings.HasPrefix(dir, wsDir+"/") {
+			continue
+		}
+		if len(wsDir) > bestLen {
+			best = key
+			bestLen = len(wsDir)
+		}
+	}
+	return best
 }
 
 func assignWorkspaceKeys(infos []domain.PackageInfo) {
@@ -101,11 +221,38 @@ func assignWorkspaceKeys(infos []domain.PackageInfo) {
 func (s Snapshot) ScriptTargets(prefix string) []string {
 	items := make([]string, 0)
 	for name := range s.Root.Scripts {
-		items = append(items, name)
+		items = append(items, s.rootTarget(name))
+	}
+	if current, ok := s.ByWorkspace[s.Current]; ok {
+		for script := range current.Scripts {
+			items = append(items, script)
+		}
 	}
 	for workspace, pkg := range s.ByWorkspace {
 		for script := range pkg.Scripts {
 			items = append(items, workspace+"/"+script)
+			if pkg.Name != "" {
+				items = append(items, pkg.Name+domain.TargetSeparator+script)
+			}
+		}
+	}
+	return filterAndSort(items, prefix)
+}
+
+// rootTarget qualifies a root script or dependency with "./" when bare names
+// resolve to the current workspace instead of the root.
+func (s Snapshot) rootTarget(name string) string {
+	if s.Current == "" {
+		return name
+	}
+	return domain.RootWorkspace + "/" + name
+}
+
+func (s Snapshot) WorkspaceScriptNames(prefix string) []string {
+	items := make([]string, 0)
+	for _, pkg := range s.ByWorkspace {
+		for script := range pkg.Scripts {
+			items = append(items, script)
 		}
 	}
 	return filterAndSort(items, prefix)
@@ -114,11 +261,19 @@ func (s Snapshot) ScriptTargets(prefix string) []string {
 func (s Snapshot) PackageTargets(prefix string) []string {
 	items := make([]string, 0)
 	for name := range s.Root.Dependencies {
-		items = append(items, name)
+		items = append(items, s.rootTarget(name))
+	}
+	if current, ok := s.ByWorkspace[s.Current]; ok {
+		for dep := range current.Dependencies {
+			items = append(items, dep)
+		}
 	}
 	for workspace, pkg := range s.ByWorkspace {
 		for dep := range pkg.Dependencies {
 			items = append(items, workspace+"/"+dep)
+			if pkg.Name != "" {
+				items = append(items, pkg.Name+domain.TargetSeparator+dep)
+			}
 		}
 	}
 	return filterAndSort(items, prefix)
@@ -126,8 +281,11 @@ func (s Snapshot) PackageTargets(prefix string) []string {
 
 func (s Snapshot) WorkspaceKeys(prefix string) []string {
 	items := make([]string, 0, len(s.ByWorkspace))
-	for key := range s.ByWorkspace {
+	for key, pkg := range s.ByWorkspace {
 		items = append(items, key)
+		if pkg.Name != "" {
+			items = append(items, pkg.Name)
+		}
 	}
 	return filterAndSort(items, prefix)
 }
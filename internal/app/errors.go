@@ -15,13 +15,36 @@ var (
 	ErrConfigAlreadyExists   = errors.New("ordo config already exists")
 	ErrConfigNotFound        = errors.New("ordo config not found")
 	ErrPresetNotFound        = errors.New("preset not found")
+	ErrPresetAlreadyExists   = errors.New("preset already exists")
+	ErrInvalidPresetName     = errors.New("invalid preset name")
+	ErrPresetCycle           = errors.New("preset extends cycle")
+	ErrPresetInUse           = errors.New("preset still extended")
+	ErrInvalidPresetEntry    = errors.New("invalid preset entry")
 	ErrPresetBucketNotFound  = errors.New("preset bucket not found")
 	ErrPresetPackageNotFound = errors.New("preset package not found")
 	ErrCatalogUnsupported    = errors.New("catalogs are unsupported for package manager")
 	ErrCatalogConflict       = errors.New("catalog entry conflict")
 	ErrInvalidCatalogName    = errors.New("invalid catalog name")
+	ErrCatalogNotFound       = errors.New("catalog not found")
+	ErrCatalogInUse          = errors.New("catalog still referenced")
+	ErrCatalogCheckFailed    = errors.New("catalog check failed")
+	ErrAmbiguousManager      = errors.New("ambiguous package manager")
 )
 
+// AmbiguousManagerError reports lockfiles from several package managers with
+// nothing else to choose between them.
+type AmbiguousManagerError struct {
+	Lockfiles []string
+}
+
+func (e AmbiguousManagerError) Error() string {
+	return fmt.Sprintf("%v: found %s; pass --manager or set the packageManager field in package.json", ErrAmbiguousManager, strings.Join(e.Lockfiles, ", "))
+}
+
+func (e AmbiguousManagerError) Is(target error) bool {
+	return target == ErrAmbiguousManager
+}
+
 type GlobalPackageMissingError struct {
 	Manager      domain.PackageManager
 	Missing      []string
@@ -35,3 +58,28 @@ func (e GlobalPackageMissingError) Error() string {
 	}
 	return msg + " (checked: " + strings.Join(e.CheckedPaths, ", ") + ")"
 }
+
+// PresetApplyError reports the preset bucket whose install failed during
+// preset apply, and the buckets installed before it.
+type PresetApplyError struct {
+	Preset  string
+	Bucket  domain.PresetBucket
+	Applied []domain.PresetBucket
+	Err     error
+}
+
+func (e PresetApplyError) Error() string {
+	applied := "none"
+	if len(e.Applied) > 0 {
+		names := make([]string, 0, len(e.Applied))
+		for _, bucket := range e.Applied {
+			names = append(names, string(bucket))
+		}
+		applied = strings.Join(names, ", ")
+	}
+	return fmt.Sprintf("apply %s %s: %v (applied: %s)", e.Preset, e.Bucket, e.Err, applied)
+}
+
+func (e PresetApplyError) Unwrap() error {
+	return e.Err
+}
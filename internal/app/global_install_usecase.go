@@ -21,7 +21,12 @@ func NewGlobalInstallUseCase(runner ports.Runner) GlobalInstallUseCase {
 }
 
 func (u GlobalInstallUseCase) Run(ctx context.Context, req GlobalInstallRequest) error {
-	argv, err := domain.BuildGlobalInstallCommand(req.Manager, trimNonEmpty(req.Packages))
+	packages := trimNonEmpty(req.Packages)
+	report := ReportFrom(ctx)
+	report.setManager(req.Manager, "")
+	report.addPackages(packages...)
+
+	argv, err := domain.BuildGlobalInstallCommand(req.Manager, packages)
 	if err != nil {
 		return err
 	}
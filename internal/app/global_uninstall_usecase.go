@@ -57,6 +57,10 @@ func (u GlobalUninstallUseCase) Run(ctx context.Context, req GlobalUninstallRequ
 		}
 	}
 
+	report := ReportFrom(ctx)
+	report.setManager(req.Manager, "")
+	report.addPackages(pkgs...)
+
 	argv, err := domain.BuildGlobalUninstallCommand(req.Manager, pkgs)
 	if err != nil {
 		return err
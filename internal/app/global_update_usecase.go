@@ -21,7 +21,12 @@ func NewGlobalUpdateUseCase(runner ports.Runner) GlobalUpdateUseCase {
 }
 
 func (u GlobalUpdateUseCase) Run(ctx context.Context, req GlobalUpdateRequest) error {
-	argv, err := domain.BuildGlobalUpdateCommand(req.Manager, trimNonEmpty(req.Packages))
+	packages := trimNonEmpty(req.Packages)
+	report := ReportFrom(ctx)
+	report.setManager(req.Manager, "")
+	report.addPackages(packages...)
+
+	argv, err := domain.BuildGlobalUpdateCommand(req.Manager, packages)
 	if err != nil {
 		return err
 	}
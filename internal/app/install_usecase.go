@@ -38,7 +38,10 @@ func (u InstallUseCase) Run(ctx context.Context, req InstallRequest) error {
 		return err
 	}
 
-	argv, err := domain.BuildInstallCommand(snapshot.Manager, trimNonEmpty(req.Packages), domain.InstallOptions{
+	packages := trimNonEmpty(req.Packages)
+	ReportFrom(ctx).addPackages(packages...)
+
+	argv, err := domain.BuildInstallCommand(snapshot.Manager, packages, domain.InstallOptions{
 		Dev:      req.Dev,
 		Peer:     req.Peer,
 		Optional: req.Optional,
@@ -2,7 +2,6 @@ package app
 
 import (
 	"context"
-	"strings"
 
 	"ordo/internal/domain"
 	"ordo/internal/ports"
@@ -25,24 +24,33 @@ func (s PresetCompletionService) Buckets(_ context.Context, preset string, prefi
 	if err != nil {
 		return nil, err
 	}
-	selected, ok := cfg.Presets[strings.TrimSpace(preset)]
-	if !ok {
+	selected, err := cfg.resolvePreset(preset)
+	if err != nil {
 		return []string{}, nil
 	}
 
 	return filterAndSort(nonEmptyPresetBuckets(selected), prefix), nil
 }
 
+// SupportedBuckets completes every bucket a preset may hold, empty or not.
+func (s PresetCompletionService) SupportedBuckets(_ context.Context, prefix string) ([]string, error) {
+	return filterAndSort(domain.SupportedPresetBuckets(), prefix), nil
+}
+
 func (s PresetCompletionService) BucketPackages(_ context.Context, preset string, bucket string, prefix string) ([]string, error) {
 	parsedBucket, err := domain.ParsePresetBucket(bucket)
 	if err != nil {
 		return []string{}, nil
 	}
 
-	items, err := s.config.bucketPackages(preset, parsedBucket)
+	entries, err := s.config.bucketPackages(preset, parsedBucket)
 	if err != nil {
 		return nil, err
 	}
+	items := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		items = append(items, presetEntryPackage(entry))
+	}
 	return filterAndSort(items, prefix), nil
 }
 
@@ -121,6 +121,26 @@ func TestPresetCompletionServiceBucketPackages(t *testing.T) {
 	}
 }
 
+func TestPresetCompletionServiceBucketPackagesDropsRanges(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	svc := NewPresetCompletionService(fakeConfigStore{
+		content: []byte(`{
+  "defaultPackageManager":"pnpm",
+  "presets": {
+    "lint": {"devDependencies": ["eslint@^9", "@types/node@^20"]}
+  }
+}`),
+	})
+
+	items, err := svc.BucketPackages(context.Background(), "lint", "devDependencies", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(items) != 2 || items[0] != "@types/node" || items[1] != "eslint" {
+		t.Fatalf("unexpected items: %#v", items)
+	}
+}
+
 func TestPresetCompletionServiceMissingConfig(t *testing.T) {
 	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
 	svc := NewPresetCompletionService(fakeConfigStore{err: os.ErrNotExist})
@@ -1,29 +1,157 @@
 package app
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"os"
+	"slices"
 	"sort"
 	"strings"
 
 	"ordo/internal/config"
 	"ordo/internal/domain"
+	"ordo/internal/jsonedit"
 	"ordo/internal/ports"
 )
 
 type ordoConfig struct {
-	Presets map[string]presetConfig `json:"presets"`
+	DefaultPackageManager string                  `json:"defaultPackageManager"`
+	Presets               map[string]presetConfig `json:"presets"`
 }
 
 type presetConfig struct {
+	// Extends names presets whose buckets are merged in ahead of this one's.
+	Extends []string `json:"extends"`
+	// Catalog installs the preset through catalog references where the
+	// package manager supports catalogs; nil inherits from extended presets.
+	Catalog              *bool    `json:"catalog"`
 	Dependencies         []string `json:"dependencies"`
 	DevDependencies      []string `json:"devDependencies"`
 	PeerDependencies     []string `json:"peerDependencies"`
 	OptionalDependencies []string `json:"optionalDependencies"`
 }
 
+// resolvePreset returns the preset with every extended preset merged in,
+// depth first and in declaration order, so inherited packages come before
+// the preset's own.
+func (c ordoConfig) resolvePreset(name string) (presetConfig, error) {
+	return c.resolvePresetChain(strings.TrimSpace(name), nil)
+}
+
+// presetsExtending returns the sorted names of presets that extend name.
+func (c ordoConfig) presetsExtending(name string) []string {
+	out := []string{}
+	for child, preset := range c.Presets {
+		if slices.Contains(trimUnique(preset.Extends), name) {
+			out = append(out, child)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+func (c ordoConfig) resolvePresetChain(name string, chain []string) (presetConfig, error) {
+	if slices.Contains(chain, name) {
+		return presetConfig{}, fmt.Errorf("%w: %s", ErrPresetCycle, strings.Join(append(chain, name), " -> "))
+	}
+	preset, ok := c.Presets[name]
+	if !ok {
+		if len(chain) > 0 {
+			return presetConfig{}, fmt.Errorf("%w: %s (extended by %s)", ErrPresetNotFound, name, chain[len(chain)-1])
+		}
+		return presetConfig{}, fmt.Errorf("%w: %s", ErrPresetNotFound, name)
+	}
+
+	resolved := presetConfig{}
+	for _, parent := range trimUnique(preset.Extends) {
+		inherited, err := c.resolvePresetChain(parent, append(slices.Clone(chain), name))
+		if err != nil {
+			return presetConfig{}, err
+		}
+		resolved = resolved.merge(inherited)
+	}
+	return resolved.merge(preset), nil
+}
+
+// merge appends other's buckets to p's, dropping blanks. An entry for a
+// package p already holds replaces it in place, so a preset can pin a
+// different range than the one it extends.
+func (p presetConfig) merge(other presetConfig) presetConfig {
+	catalog := p.Catalog
+	if other.Catalog != nil {
+		catalog = other.Catalog
+	}
+	return presetConfig{
+		Catalog:              catalog,
+		Dependencies:         mergePresetEntries(p.Dependencies, other.Dependencies),
+		DevDependencies:      mergePresetEntries(p.DevDependencies, other.DevDependencies),
+		PeerDependencies:     mergePresetEntries(p.PeerDependencies, other.PeerDependencies),
+		OptionalDependencies: mergePresetEntries(p.OptionalDependencies, other.OptionalDependencies),
+	}
+}
+
+func (p presetConfig) usesCatalog() bool {
+	return p.Catalog != nil && *p.Catalog
+}
+
+func mergePresetEntries(base []string, overrides []string) []string {
+	out := trimUnique(base)
+	for _, entry := range trimUnique(overrides) {
+		name := presetEntryPackage(entry)
+		index := slices.IndexFunc(out, func(existing string) bool { return presetEntryPackage(existing) == name })
+		if index >= 0 {
+			out[index] = entry
+			continue
+		}
+		out = append(out, entry)
+	}
+	return out
+}
+
+// presetEntryPackage returns the package name of a name or name@range entry,
+// or the entry itself when it does not parse.
+func presetEntryPackage(entry string) string {
+	spec, err := domain.ParseCatalogSpec(entry)
+	if err != nil {
+		return strings.TrimSpace(entry)
+	}
+	return spec.Package
+}
+
+// presetEntryMatches reports whether entry is requested, either as the whole
+// entry or by its package name.
+func presetEntryMatches(entry string, requested string) bool {
+	entry = strings.TrimSpace(entry)
+	return entry == requested || presetEntryPackage(entry) == requested
+}
+
+// validatePresetEntries checks that each entry is a name or name@range spec.
+func validatePresetEntries(preset string, bucket domain.PresetBucket, entries []string) error {
+	for _, entry := range entries {
+		if _, err := domain.ParseCatalogSpec(entry); err != nil {
+			return fmt.Errorf("%w: %s/%s: %v", ErrInvalidPresetEntry, preset, bucket, err)
+		}
+	}
+	return nil
+}
+
+func (p presetConfig) bucket(bucket domain.PresetBucket) []string {
+	switch bucket {
+	case domain.BucketDependencies:
+		return p.Dependencies
+	case domain.BucketDevDependencies:
+		return p.DevDependencies
+	case domain.BucketPeerDependencies:
+		return p.PeerDependencies
+	case domain.BucketOptionalDependencies:
+		return p.OptionalDependencies
+	default:
+		return nil
+	}
+}
+
 type presetConfigService struct {
 	configStore ports.ConfigStore
 }
@@ -37,15 +165,29 @@ func (s presetConfigService) load() (ordoConfig, error) {
 	if err != nil {
 		return ordoConfig{}, err
 	}
+	return s.loadPath(path)
+}
 
+func (s presetConfigService) loadPath(path string) (ordoConfig, error) {
+	payload, err := s.readPath(path)
+	if err != nil {
+		return ordoConfig{}, err
+	}
+	return parseOrdoConfig(path, payload)
+}
+
+func (s presetConfigService) readPath(path string) ([]byte, error) {
 	payload, err := s.configStore.ReadFile(path)
 	if err != nil {
 		if errors.Is(err, os.ErrNotExist) {
-			return ordoConfig{}, ErrConfigNotFound
+			return nil, ErrConfigNotFound
 		}
-		return ordoConfig{}, err
+		return nil, err
 	}
+	return payload, nil
+}
 
+func parseOrdoConfig(path string, payload []byte) (ordoConfig, error) {
 	var cfg ordoConfig
 	if err := json.Unmarshal(payload, &cfg); err != nil {
 		return ordoConfig{}, fmt.Errorf("parse %s: %w", path, err)
@@ -56,6 +198,36 @@ func (s presetConfigService) load() (ordoConfig, error) {
 	return cfg, nil
 }
 
+// edit applies change to the user ordo.json in place, so formatting and key
+// order outside the edited values survive.
+func (s presetConfigService) edit(change func(cfg ordoConfig, doc *jsonedit.Document) error) error {
+	path, err := config.OrdoConfigPath()
+	if err != nil {
+		return err
+	}
+	payload, err := s.readPath(path)
+	if err != nil {
+		return err
+	}
+	cfg, err := parseOrdoConfig(path, payload)
+	if err != nil {
+		return err
+	}
+	doc, err := jsonedit.Parse(payload)
+	if err != nil {
+		return fmt.Errorf("parse %s: %w", path, err)
+	}
+
+	if err := change(cfg, doc); err != nil {
+		return err
+	}
+	formatted := doc.Bytes()
+	if bytes.Equal(formatted, payload) {
+		return nil
+	}
+	return s.configStore.WriteFile(path, formatted, 0o644)
+}
+
 func (s presetConfigService) presetNames(prefix string) ([]string, error) {
 	cfg, err := s.load()
 	if err != nil {
@@ -73,35 +245,34 @@ func (s presetConfigService) presetNames(prefix string) ([]string, error) {
 	return items, nil
 }
 
-func (s presetConfigService) bucketPackages(name string, bucket domain.PresetBucket) ([]string, error) {
+// preset loads the user ordo.json and resolves the named preset.
+func (s presetConfigService) preset(name string) (presetConfig, error) {
 	cfg, err := s.load()
 	if err != nil {
-		return nil, err
-	}
-
-	preset, ok := cfg.Presets[strings.TrimSpace(name)]
-	if !ok {
-		return nil, fmt.Errorf("%w: %s", ErrPresetNotFound, name)
+		return presetConfig{}, err
 	}
+	return cfg.resolvePreset(name)
+}
 
-	var packages []string
-	switch bucket {
-	case domain.BucketDependencies:
-		packages = preset.Dependencies
-	case domain.BucketDevDependencies:
-		packages = preset.DevDependencies
-	case domain.BucketPeerDependencies:
-		packages = preset.PeerDependencies
-	case domain.BucketOptionalDependencies:
-		packages = preset.OptionalDependencies
-	default:
-		packages = nil
+// bucketPackages returns the validated name or name@range entries of a preset
+// bucket.
+func (s presetConfigService) bucketPackages(name string, bucket domain.PresetBucket) ([]string, error) {
+	preset, err := s.preset(name)
+	if err != nil {
+		return nil, err
 	}
+	return presetBucketEntries(preset, name, bucket)
+}
 
+func presetBucketEntries(preset presetConfig, name string, bucket domain.PresetBucket) ([]string, error) {
+	packages := trimUnique(preset.bucket(bucket))
 	if len(packages) == 0 {
 		return nil, fmt.Errorf("%w: %s/%s", ErrPresetBucketNotFound, name, bucket)
 	}
-	return trimUnique(packages), nil
+	if err := validatePresetEntries(name, bucket, packages); err != nil {
+		return nil, err
+	}
+	return packages, nil
 }
 
 func trimUnique(items []string) []string {
@@ -0,0 +1,306 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"slices"
+	"sort"
+	"strings"
+
+	"ordo/internal/domain"
+	"ordo/internal/jsonedit"
+	"ordo/internal/ports"
+)
+
+type PresetCreateRequest struct {
+	Name string
+}
+
+type PresetAddRequest struct {
+	Name     string
+	Bucket   string
+	Packages []string
+}
+
+type PresetRemoveRequest struct {
+	Name     string
+	Bucket   string
+	Packages []string
+}
+
+type PresetDeleteRequest struct {
+	Name string
+}
+
+type PresetListRequest struct {
+	// Names limits the listing to these presets; empty lists every preset.
+	Names []string
+}
+
+// PresetListing is a preset with its non-empty buckets in schema order,
+// extended presets already merged in.
+type PresetListing struct {
+	Name    string
+	Extends []string
+	Buckets []PresetBucketListing
+}
+
+type PresetBucketListing struct {
+	Bucket   domain.PresetBucket
+	Packages []string
+}
+
+type PresetSaveRequest struct {
+	Name string
+	// Workspace is the workspace key to read; empty means the current
+	// workspace, else the root.
+	Workspace string
+	// Include keeps only packages matching one of these globs.
+	Include []string
+	// Exclude drops packages matching any of these globs.
+	Exclude []string
+	// Force replaces an existing preset of the same name.
+	Force bool
+}
+
+// PresetConfigUseCase edits the presets in the user ordo.json.
+type PresetConfigUseCase struct {
+	discovery DiscoveryService
+	config    presetConfigService
+}
+
+func NewPresetConfigUseCase(discovery DiscoveryService, configStore ports.ConfigStore) PresetConfigUseCase {
+	return PresetConfigUseCase{discovery: discovery, config: newPresetConfigService(configStore)}
+}
+
+func (u PresetConfigUseCase) RunCreate(_ context.Context, req PresetCreateRequest) error {
+	name, err := presetName(req.Name)
+	if err != nil {
+		return err
+	}
+
+	return u.config.edit(func(cfg ordoConfig, doc *jsonedit.Document) error {
+		if _, ok := cfg.Presets[name]; ok {
+			return fmt.Errorf("%w: %s", ErrPresetAlreadyExists, name)
+		}
+		return doc.Set([]string{"presets", name}, map[string]any{})
+	})
+}
+
+func (u PresetConfigUseCase) RunAdd(ctx context.Context, req PresetAddRequest) error {
+	name, err := presetName(req.Name)
+	if err != nil {
+		return err
+	}
+	bucket, err := domain.ParsePresetBucket(req.Bucket)
+	if err != nil {
+		return err
+	}
+	packages := trimUnique(req.Packages)
+	if len(packages) == 0 {
+		return fmt.Errorf("no packages provided")
+	}
+	if err := validatePresetEntries(name, bucket, packages); err != nil {
+		return err
+	}
+
+	return u.config.edit(func(cfg ordoConfig, doc *jsonedit.Document) error {
+		preset, ok := cfg.Presets[name]
+		if !ok {
+			return fmt.Errorf("%w: %s (create it with ordo preset create)", ErrPresetNotFound, name)
+		}
+
+		current := preset.bucket(bucket)
+		next := mergePresetEntries(current, packages)
+		if slices.Equal(next, trimUnique(current)) {
+			return nil
+		}
+		for _, entry := range packages {
+			ReportFrom(ctx).addPackages(presetEntryPackage(entry))
+		}
+		return doc.Set([]string{"presets", name, string(bucket)}, next)
+	})
+}
+
+func (u PresetConfigUseCase) RunRemove(ctx context.Context, req PresetRemoveRequest) error {
+	name, err := presetName(req.Name)
+	if err != nil {
+		return err
+	}
+	bucket, err := domain.ParsePresetBucket(req.Bucket)
+	if err != nil {
+		return err
+	}
+	packages := trimUnique(req.Packages)
+	if len(packages) == 0 {
+		return fmt.Errorf("no packages provided")
+	}
+
+	return u.config.edit(func(cfg ordoConfig, doc *jsonedit.Document) error {
+		preset, ok := cfg.Presets[name]
+		if !ok {
+			return fmt.Errorf("%w: %s", ErrPresetNotFound, name)
+		}
+
+		current := preset.bucket(bucket)
+		for _, pkg := range packages {
+			if !slices.ContainsFunc(current, func(entry string) bool { return presetEntryMatches(entry, pkg) }) {
+				return fmt.Errorf("%w: %s/%s/%s", ErrPresetPackageNotFound, name, bucket, pkg)
+			}
+		}
+
+		next := []string{}
+		for _, entry := range current {
+			if !slices.ContainsFunc(packages, func(pkg string) bool { return presetEntryMatches(entry, pkg) }) {
+				next = append(next, entry)
+			}
+		}
+		ReportFrom(ctx).addPackages(packages...)
+		if len(trimUnique(next)) == 0 {
+			return doc.Delete([]string{"presets", name, string(bucket)})
+		}
+		return doc.Set([]string{"presets", name, string(bucket)}, next)
+	})
+}
+
+func (u PresetConfigUseCase) RunDelete(_ context.Context, req PresetDeleteRequest) error {
+	name, err := presetName(req.Name)
+	if err != nil {
+		return err
+	}
+
+	return u.config.edit(func(cfg ordoConfig, doc *jsonedit.Document) error {
+		if _, ok := cfg.Presets[name]; !ok {
+			return fmt.Errorf("%w: %s", ErrPresetNotFound, name)
+		}
+		if children := cfg.presetsExtending(name); len(children) > 0 {
+			return fmt.Errorf("%w: %s is extended by %s", ErrPresetInUse, name, strings.Join(children, ", "))
+		}
+		return doc.Delete([]string{"presets", name})
+	})
+}
+
+// RunSave writes a preset holding the dependency buckets of a workspace.
+// Workspace-protocol dependencies are left out since they only resolve inside
+// this repository.
+func (u PresetConfigUseCase) RunSave(ctx context.Context, req PresetSaveRequest) (PresetListing, error) {
+	name, err := presetName(req.Name)
+	if err != nil {
+		return PresetListing{}, err
+	}
+	for _, pattern := range append(append([]string{}, req.Include...), req.Exclude...) {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return PresetListing{}, fmt.Errorf("invalid glob %q: %w", pattern, err)
+		}
+	}
+
+	snapshot, err := u.discovery.Snapshot(ctx)
+	if err != nil {
+		return PresetListing{}, err
+	}
+	workspace, err := resolveInstallTargetPackage(snapshot, req.Workspace)
+	if err != nil {
+		return PresetListing{}, err
+	}
+
+	listing := PresetListing{Name: name, Buckets: []PresetBucketListing{}}
+	for _, raw := range domain.SupportedPresetBuckets() {
+		bucket := domain.PresetBucket(raw)
+		deps := workspace.DependencyBuckets[bucket]
+		packages := []string{}
+		for _, pkg := range sortedPackageNames(deps) {
+			if strings.HasPrefix(deps[pkg], "workspace:") || !matchesPackageGlobs(pkg, req.Include, req.Exclude) {
+				continue
+			}
+			packages = append(packages, pkg)
+		}
+		if len(packages) > 0 {
+			listing.Buckets = append(listing.Buckets, PresetBucketListing{Bucket: bucket, Packages: packages})
+		}
+	}
+	if len(listing.Buckets) == 0 {
+		return PresetListing{}, fmt.Errorf("%w: no dependencies in %s matched", ErrPackageNotFound, workspaceUsageKey(workspace))
+	}
+
+	err = u.config.edit(func(cfg ordoConfig, doc *jsonedit.Document) error {
+		if _, ok := cfg.Presets[name]; ok && !req.Force {
+			return fmt.Errorf("%w: %s (pass --force to replace it)", ErrPresetAlreadyExists, name)
+		}
+		if err := doc.Set([]string{"presets", name}, map[string]any{}); err != nil {
+			return err
+		}
+		for _, bucket := range listing.Buckets {
+			if err := doc.Set([]string{"presets", name, string(bucket.Bucket)}, bucket.Packages); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return PresetListing{}, err
+	}
+	for _, bucket := range listing.Buckets {
+		ReportFrom(ctx).addPackages(bucket.Packages...)
+	}
+	return listing, nil
+}
+
+func matchesPackageGlobs(pkg string, include []string, exclude []string) bool {
+	for _, pattern := range exclude {
+		if ok, _ := path.Match(pattern, pkg); ok {
+			return false
+		}
+	}
+	if len(include) == 0 {
+		return true
+	}
+	for _, pattern := range include {
+		if ok, _ := path.Match(pattern, pkg); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func (u PresetConfigUseCase) RunList(_ context.Context, req PresetListRequest) ([]PresetListing, error) {
+	cfg, err := u.config.load()
+	if err != nil {
+		return nil, err
+	}
+
+	names := trimUnique(req.Names)
+	if len(names) == 0 {
+		for name := range cfg.Presets {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+	}
+
+	listings := make([]PresetListing, 0, len(names))
+	for _, name := range names {
+		preset, err := cfg.resolvePreset(name)
+		if err != nil {
+			return nil, err
+		}
+		listing := PresetListing{Name: name, Buckets: []PresetBucketListing{}}
+		if extends := trimUnique(cfg.Presets[name].Extends); len(extends) > 0 {
+			listing.Extends = extends
+		}
+		for _, raw := range domain.SupportedPresetBuckets() {
+			bucket := domain.PresetBucket(raw)
+			if packages := trimUnique(preset.bucket(bucket)); len(packages) > 0 {
+				listing.Buckets = append(listing.Buckets, PresetBucketListing{Bucket: bucket, Packages: packages})
+			}
+		}
+		listings = append(listings, listing)
+	}
+	return listings, nil
+}
+
+func presetName(raw string) (string, error) {
+	if err := domain.ValidatePresetName(raw); err != nil {
+		return "", fmt.Errorf("%w: %v", ErrInvalidPresetName, err)
+	}
+	return strings.TrimSpace(raw), nil
+}
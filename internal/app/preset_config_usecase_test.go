@@ -0,0 +1,230 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	fsadapter "ordo/internal/adapters/fs"
+	"ordo/internal/domain"
+)
+
+func writeUserConfig(t *testing.T, content string) string {
+	t.Helper()
+	xdg := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", xdg)
+	path := filepath.Join(xdg, "ordo", "ordo.json")
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatalf("MkdirAll() error = %v", err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	return path
+}
+
+func assertConfigContent(t *testing.T, path string, want string) {
+	t.Helper()
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	if string(got) != want {
+		t.Fatalf("ordo.json =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestPresetConfigUseCaseEditsPresets(t *testing.T) {
+	path := writeUserConfig(t, `{
+  "$schema": "https://example.com/schema.json",
+  "defaultPackageManager": "pnpm"
+}
+`)
+	uc := NewPresetConfigUseCase(DiscoveryService{}, fsadapter.NewConfigStore())
+	ctx := context.Background()
+
+	if err := uc.RunCreate(ctx, PresetCreateRequest{Name: "lint"}); err != nil {
+		t.Fatalf("RunCreate() error = %v", err)
+	}
+	if err := uc.RunAdd(ctx, PresetAddRequest{Name: "lint", Bucket: "devDependencies", Packages: []string{"eslint", "prettier"}}); err != nil {
+		t.Fatalf("RunAdd() error = %v", err)
+	}
+	if err := uc.RunAdd(ctx, PresetAddRequest{Name: "lint", Bucket: "devDependencies", Packages: []string{"prettier", "typescript-eslint"}}); err != nil {
+		t.Fatalf("RunAdd() error = %v", err)
+	}
+	assertConfigContent(t, path, `{
+  "$schema": "https://example.com/schema.json",
+  "defaultPackageManager": "pnpm",
+  "presets": {
+    "lint": {
+      "devDependencies": [
+        "eslint",
+        "prettier",
+        "typescript-eslint"
+      ]
+    }
+  }
+}
+`)
+
+	if err := uc.RunRemove(ctx, PresetRemoveRequest{Name: "lint", Bucket: "devDependencies", Packages: []string{"prettier"}}); err != nil {
+		t.Fatalf("RunRemove() error = %v", err)
+	}
+	listings, err := uc.RunList(ctx, PresetListRequest{})
+	if err != nil {
+		t.Fatalf("RunList() error = %v", err)
+	}
+	want := []PresetListing{{Name: "lint", Buckets: []PresetBucketListing{
+		{Bucket: domain.BucketDevDependencies, Packages: []string{"eslint", "typescript-eslint"}},
+	}}}
+	if !reflect.DeepEqual(listings, want) {
+		t.Fatalf("listings = %#v, want %#v", listings, want)
+	}
+
+	if err := uc.RunRemove(ctx, PresetRemoveRequest{Name: "lint", Bucket: "devDependencies", Packages: []string{"eslint", "typescript-eslint"}}); err != nil {
+		t.Fatalf("RunRemove() error = %v", err)
+	}
+	if err := uc.RunDelete(ctx, PresetDeleteRequest{Name: "lint"}); err != nil {
+		t.Fatalf("RunDelete() error = %v", err)
+	}
+	assertConfigContent(t, path, `{
+  "$schema": "https://example.com/schema.json",
+  "defaultPackageManager": "pnpm",
+  "presets": {}
+}
+`)
+}
+
+func TestPresetConfigUseCaseErrors(t *testing.T) {
+	writeUserConfig(t, `{"defaultPackageManager":"pnpm","presets":{"lint":{"devDependencies":["eslint"]}}}`)
+	uc := NewPresetConfigUseCase(DiscoveryService{}, fsadapter.NewConfigStore())
+	ctx := context.Background()
+
+	if err := uc.RunCreate(ctx, PresetCreateRequest{Name: "lint"}); !errors.Is(err, ErrPresetAlreadyExists) {
+		t.Fatalf("RunCreate(existing) error = %v, want ErrPresetAlreadyExists", err)
+	}
+	if err := uc.RunCreate(ctx, PresetCreateRequest{Name: "my preset"}); !errors.Is(err, ErrInvalidPresetName) {
+		t.Fatalf("RunCreate(invalid) error = %v, want ErrInvalidPresetName", err)
+	}
+	if err := uc.RunAdd(ctx, PresetAddRequest{Name: "test", Bucket: "devDependencies", Packages: []string{"vitest"}}); !errors.Is(err, ErrPresetNotFound) {
+		t.Fatalf("RunAdd(missing preset) error = %v, want ErrPresetNotFound", err)
+	}
+	if err := uc.RunAdd(ctx, PresetAddRequest{Name: "lint", Bucket: "scripts", Packages: []string{"vitest"}}); err == nil {
+		t.Fatal("RunAdd(invalid bucket) error = nil, want non-nil")
+	}
+	if err := uc.RunRemove(ctx, PresetRemoveRequest{Name: "lint", Bucket: "devDependencies", Packages: []string{"prettier"}}); !errors.Is(err, ErrPresetPackageNotFound) {
+		t.Fatalf("RunRemove(missing package) error = %v, want ErrPresetPackageNotFound", err)
+	}
+	if err := uc.RunDelete(ctx, PresetDeleteRequest{Name: "test"}); !errors.Is(err, ErrPresetNotFound) {
+		t.Fatalf("RunDelete(missing) error = %v, want ErrPresetNotFound", err)
+	}
+	if _, err := uc.RunList(ctx, PresetListRequest{Names: []string{"test"}}); !errors.Is(err, ErrPresetNotFound) {
+		t.Fatalf("RunList(missing) error = %v, want ErrPresetNotFound", err)
+	}
+}
+
+func TestPresetConfigUseCaseVersionSpecs(t *testing.T) {
+	path := writeUserConfig(t, `{"defaultPackageManager":"pnpm","presets":{"lint":{"devDependencies":["eslint","prettier"]}}}`)
+	uc := NewPresetConfigUseCase(DiscoveryService{}, fsadapter.NewConfigStore())
+	ctx := context.Background()
+
+	if err := uc.RunAdd(ctx, PresetAddRequest{Name: "lint", Bucket: "devDependencies", Packages: []string{"eslint@^9", "@types/node@^20"}}); err != nil {
+		t.Fatalf("RunAdd() error = %v", err)
+	}
+	if err := uc.RunRemove(ctx, PresetRemoveRequest{Name: "lint", Bucket: "devDependencies", Packages: []string{"@types/node"}}); err != nil {
+		t.Fatalf("RunRemove() error = %v", err)
+	}
+	if err := uc.RunAdd(ctx, PresetAddRequest{Name: "lint", Bucket: "devDependencies", Packages: []string{"vitest@"}}); !errors.Is(err, ErrInvalidPresetEntry) {
+		t.Fatalf("RunAdd(invalid spec) error = %v, want ErrInvalidPresetEntry", err)
+	}
+	assertConfigContent(t, path, `{"defaultPackageManager":"pnpm","presets":{"lint":{"devDependencies":["eslint@^9","prettier"]}}}`)
+}
+
+func TestPresetConfigUseCaseDeleteRefusesExtendedPreset(t *testing.T) {
+	content := `{"defaultPackageManager":"pnpm","presets":{"base":{"devDependencies":["eslint"]},"web":{"extends":["base"]},"lib":{"extends":["base"]}}}`
+	path := writeUserConfig(t, content)
+	uc := NewPresetConfigUseCase(DiscoveryService{}, fsadapter.NewConfigStore())
+
+	err := uc.RunDelete(context.Background(), PresetDeleteRequest{Name: "base"})
+	if !errors.Is(err, ErrPresetInUse) {
+		t.Fatalf("RunDelete() error = %v, want ErrPresetInUse", err)
+	}
+	if !strings.Contains(err.Error(), "lib, web") {
+		t.Fatalf("RunDelete() error = %v, want the extending presets named", err)
+	}
+	assertConfigContent(t, path, content)
+}
+
+func TestPresetConfigUseCaseRequiresConfig(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	uc := NewPresetConfigUseCase(DiscoveryService{}, fsadapter.NewConfigStore())
+
+	if err := uc.RunCreate(context.Background(), PresetCreateRequest{Name: "lint"}); !errors.Is(err, ErrConfigNotFound) {
+		t.Fatalf("RunCreate() error = %v, want ErrConfigNotFound", err)
+	}
+}
+
+func TestPresetConfigUseCaseSaveFromWorkspace(t *testing.T) {
+	path := writeUserConfig(t, `{
+  "defaultPackageManager": "pnpm",
+  "presets": {
+    "lint": {
+      "devDependencies": ["eslint"]
+    }
+  }
+}
+`)
+	infos := fixtureInfos()
+	infos[1].DependencyBuckets = map[domain.PresetBucket]map[string]string{
+		domain.BucketDependencies: {"@acme/utils": "workspace:*", "clsx": "^2.1.1"},
+		domain.BucketDevDependencies: {
+			"@typescript-eslint/parser": "^8.0.0",
+			"eslint":                    "^9.0.0",
+			"eslint-plugin-react":       "^7.0.0",
+			"vitest":                    "^2.0.0",
+		},
+	}
+	uc := NewPresetConfigUseCase(NewDiscoveryService(fakeIndexer{infos: infos}), fsadapter.NewConfigStore())
+	ctx := context.Background()
+
+	req := PresetSaveRequest{Name: "lint", Workspace: "ui", Include: []string{"eslint*", "@typescript-eslint/*"}, Exclude: []string{"eslint-plugin-*"}}
+	if _, err := uc.RunSave(ctx, req); !errors.Is(err, ErrPresetAlreadyExists) {
+		t.Fatalf("RunSave(existing) error = %v, want ErrPresetAlreadyExists", err)
+	}
+
+	req.Force = true
+	listing, err := uc.RunSave(ctx, req)
+	if err != nil {
+		t.Fatalf("RunSave() error = %v", err)
+	}
+	want := PresetListing{Name: "lint", Buckets: []PresetBucketListing{
+		{Bucket: domain.BucketDevDependencies, Packages: []string{"@typescript-eslint/parser", "eslint"}},
+	}}
+	if !reflect.DeepEqual(listing, want) {
+		t.Fatalf("listing = %#v, want %#v", listing, want)
+	}
+	assertConfigContent(t, path, `{
+  "defaultPackageManager": "pnpm",
+  "presets": {
+    "lint": {
+      "devDependencies": [
+        "@typescript-eslint/parser",
+        "eslint"
+      ]
+    }
+  }
+}
+`)
+
+	listing, err = uc.RunSave(ctx, PresetSaveRequest{Name: "ui", Workspace: "ui"})
+	if err != nil {
+		t.Fatalf("RunSave() error = %v", err)
+	}
+	if len(listing.Buckets) != 2 || !reflect.DeepEqual(listing.Buckets[0].Packages, []string{"clsx"}) {
+		t.Fatalf("workspace-protocol dependencies should be skipped: %#v", listing)
+	}
+}
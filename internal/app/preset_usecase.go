@@ -3,6 +3,7 @@ package app
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strings"
 
 	"ordo/internal/domain"
@@ -14,23 +15,53 @@ type PresetRequest struct {
 	Bucket    string
 	Packages  []string
 	Workspace string
+	// Force overrides conflicting catalog entries for presets that set
+	// "catalog".
+	Force bool
+}
+
+type PresetApplyRequest struct {
+	Preset    string
+	Workspace string
+	Force     bool
+}
+
+// PresetApplication lists the preset buckets installed into a workspace, in
+// the order they ran.
+type PresetApplication struct {
+	Preset    string
+	Workspace string
+	Applied   []domain.PresetBucket
 }
 
 type PresetUseCase struct {
 	discovery DiscoveryService
 	runner    ports.Runner
 	config    presetConfigService
+	catalog   CatalogUseCase
 }
 
 func NewPresetUseCase(
 	discovery DiscoveryService,
 	runner ports.Runner,
 	configStore ports.ConfigStore,
+) PresetUseCase {
+	return NewPresetUseCaseWithCatalogs(discovery, runner, configStore, CatalogUseCase{})
+}
+
+// NewPresetUseCaseWithCatalogs also lets presets that set "catalog" write
+// their packages to the default catalog the way `catalog add` does.
+func NewPresetUseCaseWithCatalogs(
+	discovery DiscoveryService,
+	runner ports.Runner,
+	configStore ports.ConfigStore,
+	catalog CatalogUseCase,
 ) PresetUseCase {
 	return PresetUseCase{
 		discovery: discovery,
 		runner:    runner,
 		config:    newPresetConfigService(configStore),
+		catalog:   catalog,
 	}
 }
 
@@ -50,7 +81,11 @@ func (u PresetUseCase) Run(ctx context.Context, req PresetRequest) error {
 		return err
 	}
 
-	packages, err := u.config.bucketPackages(req.Preset, bucket)
+	preset, err := u.config.preset(req.Preset)
+	if err != nil {
+		return err
+	}
+	packages, err := presetBucketEntries(preset, req.Preset, bucket)
 	if err != nil {
 		return err
 	}
@@ -59,8 +94,71 @@ func (u PresetUseCase) Run(ctx context.Context, req PresetRequest) error {
 	if err != nil {
 		return err
 	}
+	return u.installBucket(ctx, snapshot, target, preset, bucket, selected, req.Force)
+}
+
+// RunApply installs every non-empty bucket of a preset in schema order. It
+// stops at the first bucket that fails; the returned application lists the
+// buckets installed before it.
+func (u PresetUseCase) RunApply(ctx context.Context, req PresetApplyRequest) (PresetApplication, error) {
+	snapshot, err := u.discovery.Snapshot(ctx)
+	if err != nil {
+		return PresetApplication{}, err
+	}
+
+	target, err := resolveInstallTargetPackage(snapshot, req.Workspace)
+	if err != nil {
+		return PresetApplication{}, err
+	}
 
-	argv, err := domain.BuildInstallCommand(snapshot.Manager, selected, domain.BucketInstallOptions(bucket))
+	preset, err := u.config.preset(req.Preset)
+	if err != nil {
+		return PresetApplication{}, err
+	}
+
+	name := strings.TrimSpace(req.Preset)
+	buckets := []domain.PresetBucket{}
+	entries := map[domain.PresetBucket][]string{}
+	for _, raw := range domain.SupportedPresetBuckets() {
+		bucket := domain.PresetBucket(raw)
+		packages := trimUnique(preset.bucket(bucket))
+		if len(packages) == 0 {
+			continue
+		}
+		if err := validatePresetEntries(name, bucket, packages); err != nil {
+			return PresetApplication{}, err
+		}
+		buckets = append(buckets, bucket)
+		entries[bucket] = packages
+	}
+	if len(buckets) == 0 {
+		return PresetApplication{}, fmt.Errorf("%w: %s has no packages", ErrPresetBucketNotFound, name)
+	}
+
+	result := PresetApplication{Preset: name, Workspace: workspaceUsageKey(target), Applied: []domain.PresetBucket{}}
+	for _, bucket := range buckets {
+		if err := u.installBucket(ctx, snapshot, target, preset, bucket, entries[bucket], req.Force); err != nil {
+			return result, PresetApplyError{Preset: name, Bucket: bucket, Applied: result.Applied, Err: err}
+		}
+		result.Applied = append(result.Applied, bucket)
+	}
+	return result, nil
+}
+
+func (u PresetUseCase) installBucket(ctx context.Context, snapshot Snapshot, target domain.PackageInfo, preset presetConfig, bucket domain.PresetBucket, entries []string, force bool) error {
+	if preset.usesCatalog() && domain.SupportsCatalogs(snapshot.Manager) && u.catalog.catalogs != nil {
+		specs, err := u.catalogSpecs(ctx, snapshot.Manager, entries)
+		if err != nil {
+			return err
+		}
+		return u.catalog.applyAddToSection(ctx, "", specs, workspaceUsageKey(target), bucket, force)
+	}
+
+	for _, entry := range entries {
+		ReportFrom(ctx).addPackages(presetEntryPackage(entry))
+	}
+
+	argv, err := domain.BuildInstallCommand(snapshot.Manager, entries, domain.BucketInstallOptions(bucket))
 	if err != nil {
 		return err
 	}
@@ -68,12 +166,31 @@ func (u PresetUseCase) Run(ctx context.Context, req PresetRequest) error {
 	return u.runner.Run(ctx, target.Dir, argv)
 }
 
-func filterPresetPackages(available []string, requested []string) ([]string, error) {
-	availableSet := map[string]struct{}{}
-	for _, item := range available {
-		availableSet[item] = struct{}{}
+// catalogSpecs pins entries without a range to the default catalog's
+// version, so only explicit ranges can conflict with the catalog.
+func (u PresetUseCase) catalogSpecs(ctx context.Context, manager domain.PackageManager, entries []string) ([]string, error) {
+	existing, err := u.catalog.catalogs.CatalogEntries(ctx, manager, "")
+	if err != nil {
+		return nil, err
 	}
 
+	specs := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		spec, err := domain.ParseCatalogSpec(entry)
+		if err != nil {
+			return nil, err
+		}
+		if current, ok := existing[spec.Package]; ok && spec.Version == "" {
+			entry = spec.Package + "@" + current
+		}
+		specs = append(specs, entry)
+	}
+	return specs, nil
+}
+
+// filterPresetPackages selects the entries whose package name, or whole
+// name@range entry, was requested.
+func filterPresetPackages(available []string, requested []string) ([]string, error) {
 	if len(requested) == 0 {
 		return available, nil
 	}
@@ -85,14 +202,16 @@ func filterPresetPackages(available []string, requested []string) ([]string, err
 		if name == "" {
 			continue
 		}
-		if _, ok := availableSet[name]; !ok {
+		index := slices.IndexFunc(available, func(entry string) bool { return presetEntryMatches(entry, name) })
+		if index < 0 {
 			return nil, fmt.Errorf("%w: %s", ErrPresetPackageNotFound, name)
 		}
-		if _, ok := seen[name]; ok {
+		entry := available[index]
+		if _, ok := seen[entry]; ok {
 			continue
 		}
-		seen[name] = struct{}{}
-		selected = append(selected, name)
+		seen[entry] = struct{}{}
+		selected = append(selected, entry)
 	}
 
 	if len(selected) == 0 {
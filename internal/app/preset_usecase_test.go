@@ -4,7 +4,10 @@ import (
 	"context"
 	"errors"
 	"os"
+	"reflect"
 	"testing"
+
+	"ordo/internal/domain"
 )
 
 type fakeConfigStore struct {
@@ -189,3 +192,339 @@ func TestPresetUseCaseMissingBucket(t *testing.T) {
 		t.Fatalf("expected ErrPresetBucketNotFound, got %v", err)
 	}
 }
+
+func TestPresetUseCaseResolvesExtends(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	runner := &fakeRunner{}
+	discovery := NewDiscoveryService(fakeIndexer{infos: fixtureInfos()})
+	uc := NewPresetUseCase(discovery, runner, fakeConfigStore{
+		content: []byte(`{
+  "defaultPackageManager": "pnpm",
+  "presets": {
+    "base": {"devDependencies": ["eslint", "prettier"]},
+    "react": {"extends": ["base"], "devDependencies": ["eslint-plugin-react"]},
+    "react-app": {"extends": ["react", "base"], "devDependencies": ["prettier", "vite"]}
+  }
+}`),
+	})
+
+	err := uc.Run(context.Background(), PresetRequest{
+		Preset: "react-app",
+		Bucket: "devDependencies",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []string{"pnpm", "add", "--save-dev", "eslint", "prettier", "eslint-plugin-react", "vite"}
+	if !reflect.DeepEqual(runner.argv, want) {
+		t.Fatalf("argv = %#v, want %#v", runner.argv, want)
+	}
+}
+
+func TestPresetUseCaseExtendsErrors(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	cases := []struct {
+		name    string
+		presets string
+		want    error
+	}{
+		{
+			name:    "cycle",
+			presets: `{"a": {"extends": ["b"]}, "b": {"extends": ["a"]}}`,
+			want:    ErrPresetCycle,
+		},
+		{
+			name:    "self",
+			presets: `{"a": {"extends": ["a"], "devDependencies": ["eslint"]}}`,
+			want:    ErrPresetCycle,
+		},
+		{
+			name:    "missing parent",
+			presets: `{"a": {"extends": ["base"], "devDependencies": ["eslint"]}}`,
+			want:    ErrPresetNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			discovery := NewDiscoveryService(fakeIndexer{infos: fixtureInfos()})
+			uc := NewPresetUseCase(discovery, &fakeRunner{}, fakeConfigStore{
+				content: []byte(`{"defaultPackageManager":"pnpm","presets":` + tc.presets + `}`),
+			})
+
+			err := uc.Run(context.Background(), PresetRequest{Preset: "a", Bucket: "devDependencies"})
+			if !errors.Is(err, tc.want) {
+				t.Fatalf("expected %v, got %v", tc.want, err)
+			}
+		})
+	}
+}
+
+func TestPresetUseCaseVersionSpecs(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	runner := &fakeRunner{}
+	discovery := NewDiscoveryService(fakeIndexer{infos: fixtureInfos()})
+	uc := NewPresetUseCase(discovery, runner, fakeConfigStore{
+		content: []byte(`{
+  "defaultPackageManager": "pnpm",
+  "presets": {
+    "base": {"devDependencies": ["eslint@^8", "@types/node@^20", "prettier"]},
+    "lint": {"extends": ["base"], "devDependencies": ["eslint@^9"]}
+  }
+}`),
+	})
+
+	err := uc.Run(context.Background(), PresetRequest{
+		Preset:   "lint",
+		Bucket:   "devDependencies",
+		Packages: []string{"eslint", "@types/node"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []string{"pnpm", "add", "--save-dev", "eslint@^9", "@types/node@^20"}
+	if !reflect.DeepEqual(runner.argv, want) {
+		t.Fatalf("argv = %#v, want %#v", runner.argv, want)
+	}
+}
+
+func TestPresetUseCaseInvalidEntry(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	discovery := NewDiscoveryService(fakeIndexer{infos: fixtureInfos()})
+	uc := NewPresetUseCase(discovery, &fakeRunner{}, fakeConfigStore{
+		content: []byte(`{"defaultPackageManager":"pnpm","presets":{"lint":{"devDependencies":["eslint@"]}}}`),
+	})
+
+	err := uc.Run(context.Background(), PresetRequest{Preset: "lint", Bucket: "devDependencies"})
+	if !errors.Is(err, ErrInvalidPresetEntry) {
+		t.Fatalf("expected ErrInvalidPresetEntry, got %v", err)
+	}
+}
+
+func TestPresetUseCaseInstallsThroughCatalog(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	runner := &fakeRunner{}
+	catalogs := &fakeCatalogStore{entriesByName: map[string]map[string]string{
+		"": {"eslint": "^9.1.0"},
+	}}
+	manifests := &fakeManifestStore{}
+	discovery := NewDiscoveryService(fakeIndexer{infos: fixtureInfos()})
+	catalogUC := NewCatalogUseCase(discovery, catalogs, manifests, fakeVersionResolver{versions: map[string]string{"prettier": "3.5.3"}})
+	uc := NewPresetUseCaseWithCatalogs(discovery, runner, fakeConfigStore{
+		content: []byte(`{
+  "defaultPackageManager": "pnpm",
+  "presets": {
+    "lint": {"catalog": true, "devDependencies": ["eslint", "prettier", "typescript@~5.6.0"]}
+  }
+}`),
+	}, catalogUC)
+
+	err := uc.Run(context.Background(), PresetRequest{Preset: "lint", Bucket: "devDependencies", Workspace: "ui"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	wantEntries := map[string]string{"eslint": "^9.1.0", "prettier": "^3.5.3", "typescript": "~5.6.0"}
+	if catalogs.name != "" || catalogs.force || !reflect.DeepEqual(catalogs.entries, wantEntries) {
+		t.Fatalf("catalog %q entries = %#v (force %v), want %#v", catalogs.name, catalogs.entries, catalogs.force, wantEntries)
+	}
+	wantSections := []string{"add packages/ui devDependencies catalog: eslint,prettier,typescript"}
+	if !reflect.DeepEqual(manifests.sections, wantSections) {
+		t.Fatalf("manifest rewrites = %#v, want %#v", manifests.sections, wantSections)
+	}
+	if manifests.dir != "" || runner.argv != nil {
+		t.Fatalf("expected only section rewrites, got dir=%q argv=%#v", manifests.dir, runner.argv)
+	}
+}
+
+// conflictingCatalogStore rejects every upsert the way the YAML and JSON
+// stores reject a changed version without force.
+type conflictingCatalogStore struct {
+	*fakeCatalogStore
+}
+
+func (conflictingCatalogStore) UpsertCatalogEntries(context.Context, domain.PackageManager, string, map[string]string, bool) error {
+	return errors.New("catalog conflict for eslint: existing ^9.1.0, requested ^8")
+}
+
+func TestPresetUseCaseCatalogConflict(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	runner := &fakeRunner{}
+	catalogs := conflictingCatalogStore{&fakeCatalogStore{entriesByName: map[string]map[string]string{
+		"": {"eslint": "^9.1.0"},
+	}}}
+	manifests := &fakeManifestStore{}
+	discovery := NewDiscoveryService(fakeIndexer{infos: fixtureInfos()})
+	uc := NewPresetUseCaseWithCatalogs(discovery, runner, fakeConfigStore{
+		content: []byte(`{"defaultPackageManager":"pnpm","presets":{"lint":{"catalog":true,"devDependencies":["eslint@^8"]}}}`),
+	}, NewCatalogUseCase(discovery, catalogs, manifests, fakeVersionResolver{}))
+
+	err := uc.Run(context.Background(), PresetRequest{Preset: "lint", Bucket: "devDependencies"})
+	if !errors.Is(err, ErrCatalogConflict) {
+		t.Fatalf("expected ErrCatalogConflict, got %v", err)
+	}
+	if manifests.sections != nil || runner.argv != nil {
+		t.Fatalf("expected no writes, got sections=%#v argv=%#v", manifests.sections, runner.argv)
+	}
+}
+
+func TestPresetUseCaseForcesCatalogEntries(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	catalogs := &fakeCatalogStore{entriesByName: map[string]map[string]string{"": {"eslint": "^9.1.0"}}}
+	manifests := &fakeManifestStore{}
+	runner := &fakeRunner{}
+	discovery := NewDiscoveryService(fakeIndexer{infos: fixtureInfos()})
+	uc := NewPresetUseCaseWithCatalogs(discovery, runner, fakeConfigStore{
+		content: []byte(`{"defaultPackageManager":"pnpm","presets":{"lint":{"catalog":true,"devDependencies":["eslint@^8"]}}}`),
+	}, NewCatalogUseCase(discovery, catalogs, manifests, fakeVersionResolver{}))
+
+	if err := uc.Run(context.Background(), PresetRequest{Preset: "lint", Bucket: "devDependencies", Workspace: ".", Force: true}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !catalogs.force || !reflect.DeepEqual(catalogs.entries, map[string]string{"eslint": "^8"}) {
+		t.Fatalf("upsert = %#v (force %v)", catalogs.entries, catalogs.force)
+	}
+	if want := []string{"add . devDependencies catalog: eslint"}; !reflect.DeepEqual(manifests.sections, want) {
+		t.Fatalf("manifest rewrites = %#v, want %#v", manifests.sections, want)
+	}
+	if runner.argv != nil {
+		t.Fatalf("expected no install command, got %#v", runner.argv)
+	}
+}
+
+func TestPresetUseCaseApplyForcesCatalogEntries(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	catalogs := &fakeCatalogStore{entriesByName: map[string]map[string]string{"": {"eslint": "^9.1.0"}}}
+	manifests := &fakeManifestStore{}
+	discovery := NewDiscoveryService(fakeIndexer{infos: fixtureInfos()})
+	uc := NewPresetUseCaseWithCatalogs(discovery, &fakeRunner{}, fakeConfigStore{
+		content: []byte(`{"defaultPackageManager":"pnpm","presets":{"lint":{"catalog":true,"dependencies":["zod@^3"],"devDependencies":["eslint@^8"]}}}`),
+	}, NewCatalogUseCase(discovery, catalogs, manifests, fakeVersionResolver{}))
+
+	result, err := uc.RunApply(context.Background(), PresetApplyRequest{Preset: "lint", Workspace: ".", Force: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !catalogs.force || !reflect.DeepEqual(catalogs.entries, map[string]string{"eslint": "^8"}) {
+		t.Fatalf("last upsert = %#v (force %v)", catalogs.entries, catalogs.force)
+	}
+	wantSections := []string{
+		"add . dependencies catalog: zod",
+		"add . devDependencies catalog: eslint",
+	}
+	if !reflect.DeepEqual(manifests.sections, wantSections) {
+		t.Fatalf("manifest rewrites = %#v, want %#v", manifests.sections, wantSections)
+	}
+	if want := []domain.PresetBucket{domain.BucketDependencies, domain.BucketDevDependencies}; !reflect.DeepEqual(result.Applied, want) {
+		t.Fatalf("applied = %#v, want %#v", result.Applied, want)
+	}
+}
+
+// failingRunner records commands and fails from call number failAt on.
+type failingRunner struct {
+	recordingRunner
+	failAt int
+}
+
+func (f *failingRunner) Run(ctx context.Context, dir string, argv []string) error {
+	_ = f.recordingRunner.Run(ctx, dir, argv)
+	if len(f.argv) >= f.failAt {
+		return errors.New("install failed")
+	}
+	return nil
+}
+
+const applyPresetConfig = `{
+  "defaultPackageManager": "pnpm",
+  "presets": {
+    "base": {"devDependencies": ["eslint"]},
+    "web": {
+      "extends": ["base"],
+      "optionalDependencies": ["fsevents"],
+      "dependencies": ["react@^19"],
+      "devDependencies": ["vite"]
+    }
+  }
+}`
+
+func TestPresetUseCaseApplyInstallsBucketsInOrder(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	runner := &recordingRunner{}
+	discovery := NewDiscoveryService(fakeIndexer{infos: fixtureInfos()})
+	uc := NewPresetUseCase(discovery, runner, fakeConfigStore{content: []byte(applyPresetConfig)})
+
+	result, err := uc.RunApply(context.Background(), PresetApplyRequest{Preset: "web", Workspace: "ui"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	wantApplied := []domain.PresetBucket{domain.BucketDependencies, domain.BucketDevDependencies, domain.BucketOptionalDependencies}
+	if result.Preset != "web" || result.Workspace != "ui" || !reflect.DeepEqual(result.Applied, wantApplied) {
+		t.Fatalf("result = %#v", result)
+	}
+	wantArgv := [][]string{
+		{"pnpm", "add", "react@^19"},
+		{"pnpm", "add", "--save-dev", "eslint", "vite"},
+		{"pnpm", "add", "--save-optional", "fsevents"},
+	}
+	if !reflect.DeepEqual(runner.argv, wantArgv) {
+		t.Fatalf("argv = %#v, want %#v", runner.argv, wantArgv)
+	}
+	for _, dir := range runner.dirs {
+		if dir != "packages/ui" {
+			t.Fatalf("ran in %s, want packages/ui", dir)
+		}
+	}
+}
+
+func TestPresetUseCaseApplyStopsAtFailedBucket(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	runner := &failingRunner{failAt: 2}
+	discovery := NewDiscoveryService(fakeIndexer{infos: fixtureInfos()})
+	uc := NewPresetUseCase(discovery, runner, fakeConfigStore{content: []byte(applyPresetConfig)})
+
+	result, err := uc.RunApply(context.Background(), PresetApplyRequest{Preset: "web"})
+	var applyErr PresetApplyError
+	if !errors.As(err, &applyErr) {
+		t.Fatalf("expected PresetApplyError, got %v", err)
+	}
+	if applyErr.Bucket != domain.BucketDevDependencies {
+		t.Fatalf("failed bucket = %s, want devDependencies", applyErr.Bucket)
+	}
+	wantApplied := []domain.PresetBucket{domain.BucketDependencies}
+	if !reflect.DeepEqual(result.Applied, wantApplied) || !reflect.DeepEqual(applyErr.Applied, wantApplied) {
+		t.Fatalf("applied = %#v / %#v, want %#v", result.Applied, applyErr.Applied, wantApplied)
+	}
+	if len(runner.argv) != 2 {
+		t.Fatalf("expected the remaining buckets to be skipped, ran %#v", runner.argv)
+	}
+}
+
+func TestPresetUseCaseApplyValidatesBeforeInstalling(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	runner := &recordingRunner{}
+	discovery := NewDiscoveryService(fakeIndexer{infos: fixtureInfos()})
+	uc := NewPresetUseCase(discovery, runner, fakeConfigStore{
+		content: []byte(`{"defaultPackageManager":"pnpm","presets":{"web":{"dependencies":["react"],"devDependencies":["vite@"]}}}`),
+	})
+
+	if _, err := uc.RunApply(context.Background(), PresetApplyRequest{Preset: "web"}); !errors.Is(err, ErrInvalidPresetEntry) {
+		t.Fatalf("expected ErrInvalidPresetEntry, got %v", err)
+	}
+	if len(runner.argv) != 0 {
+		t.Fatalf("expected nothing to run, got %#v", runner.argv)
+	}
+}
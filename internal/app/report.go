@@ -0,0 +1,177 @@
+package app
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	"ordo/internal/domain"
+)
+
+// Report collects what a command did, so the CLI can describe the outcome in a
+// machine-readable form.
+type Report struct {
+	mu       sync.Mutex
+	manager  domain.PackageManager
+	version  string
+	commands []CommandRecord
+	packages []string
+	files    []FileRecord
+	result   any
+}
+
+// CommandRecord describes one external command ordo executed.
+type CommandRecord struct {
+	Dir      string
+	Argv     []string
+	ExitCode int
+}
+
+// FileRecord describes a file a dry run would have written. Before is nil when
+// the file did not exist.
+type FileRecord struct {
+	Path   string
+	Before []byte
+	After  []byte
+}
+
+type reportKey struct{}
+
+// WithReport attaches a report that use cases fill in as they run.
+func WithReport(ctx context.Context, report *Report) context.Context {
+	return context.WithValue(ctx, reportKey{}, report)
+}
+
+// ReportFrom returns the report attached to ctx, or nil when there is none.
+// Every Report method is safe to call on a nil report.
+func ReportFrom(ctx context.Context) *Report {
+	if ctx == nil {
+		return nil
+	}
+	report, _ := ctx.Value(reportKey{}).(*Report)
+	return report
+}
+
+// RecordCommand appends an executed command and its exit status.
+func (r *Report) RecordCommand(dir string, argv []string, exitCode int) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.commands = append(r.commands, CommandRecord{
+		Dir:      dir,
+		Argv:     append([]string(nil), argv...),
+		ExitCode: exitCode,
+	})
+}
+
+// RecordFile notes a pending write to path. Repeated writes keep the original
+// content and the latest new content.
+func (r *Report) RecordFile(path string, before []byte, after []byte) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i := range r.files {
+		if r.files[i].Path == path {
+			r.files[i].After = append([]byte(nil), after...)
+			return
+		}
+	}
+	record := FileRecord{Path: path, After: append([]byte(nil), after...)}
+	if before != nil {
+		record.Before = append([]byte(nil), before...)
+	}
+	r.files = append(r.files, record)
+}
+
+// SetResult stores the data a query command produced, for structured output.
+func (r *Report) SetResult(result any) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.result = result
+}
+
+func (r *Report) Result() any {
+	if r == nil {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.result
+}
+
+func (r *Report) Manager() domain.PackageManager {
+	if r == nil {
+		return ""
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.manager
+}
+
+// ManagerVersion is the manager version pinned by the packageManager field,
+// empty when the manager was picked another way.
+func (r *Report) ManagerVersion() string {
+	if r == nil {
+		return ""
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.version
+}
+
+func (r *Report) Commands() []CommandRecord {
+	if r == nil {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]CommandRecord(nil), r.commands...)
+}
+
+func (r *Report) Packages() []string {
+	if r == nil {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.packages...)
+}
+
+func (r *Report) Files() []FileRecord {
+	if r == nil {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]FileRecord(nil), r.files...)
+}
+
+func (r *Report) setManager(manager domain.PackageManager, version string) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.manager = manager
+	r.version = version
+}
+
+func (r *Report) addPackages(packages ...string) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, pkg := range packages {
+		if !slices.Contains(r.packages, pkg) {
+			r.packages = append(r.packages, pkg)
+		}
+	}
+}
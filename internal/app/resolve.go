@@ -8,23 +8,20 @@ import (
 )
 
 func resolveTargetPackage(snapshot Snapshot, target domain.Target) (domain.PackageInfo, error) {
-	if target.IsRoot() {
-		return snapshot.Root, nil
-	}
-
-	pkg, ok := snapshot.ByWorkspace[target.Workspace]
-	if !ok {
-		return domain.PackageInfo{}, fmt.Errorf("%w: %s", ErrWorkspaceNotFound, target.Workspace)
-	}
-	return pkg, nil
+	return resolveInstallTargetPackage(snapshot, target.Workspace)
 }
 
+// resolveInstallTargetPackage maps a workspace key or package name to its package. An empty key
+// means the current workspace, or the root when ordo runs outside any workspace.
 func resolveInstallTargetPackage(snapshot Snapshot, workspace string) (domain.PackageInfo, error) {
 	key := strings.TrimSpace(workspace)
 	if key == "" {
+		key = snapshot.Current
+	}
+	if key == "" || key == domain.RootWorkspace {
 		return snapshot.Root, nil
 	}
-	pkg, ok := snapshot.ByWorkspace[key]
+	pkg, ok := snapshot.Workspace(key)
 	if !ok {
 		return domain.PackageInfo{}, fmt.Errorf("%w: %s", ErrWorkspaceNotFound, key)
 	}
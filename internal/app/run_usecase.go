@@ -2,7 +2,12 @@ package app
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
 
 	"ordo/internal/domain"
 	"ordo/internal/ports"
@@ -16,10 +21,15 @@ type RunRequest struct {
 type RunUseCase struct {
 	discovery DiscoveryService
 	runner    ports.Runner
+	streams   ports.StreamRunner
 }
 
 func NewRunUseCase(discovery DiscoveryService, runner ports.Runner) RunUseCase {
-	return RunUseCase{discovery: discovery, runner: runner}
+	return NewRunUseCaseWithStreams(discovery, runner, nil)
+}
+
+func NewRunUseCaseWithStreams(discovery DiscoveryService, runner ports.Runner, streams ports.StreamRunner) RunUseCase {
+	return RunUseCase{discovery: discovery, runner: runner, streams: streams}
 }
 
 func (u RunUseCase) Run(ctx context.Context, req RunRequest) error {
@@ -49,3 +59,213 @@ func (u RunUseCase) Run(ctx context.Context, req RunRequest) error {
 
 	return u.runner.Run(ctx, pkg.Dir, argv)
 }
+
+type RunManyRequest struct {
+	Script      string
+	All         bool
+	Filters     []string
+	ExtraArgs   []string
+	Parallel    bool
+	Concurrency int
+	// Prefix formats the line prefixes for a workspace's output in parallel
+	// mode, given the longest workspace key in the run.
+	Prefix func(workspace string, width int) ports.StreamPrefix
+}
+
+// RunMany runs one script across the selected workspaces in dependency order.
+// Workspaces that do not define the script are skipped.
+func (u RunUseCase) RunMany(ctx context.Context, req RunManyRequest) error {
+	snapshot, err := u.discovery.Snapshot(ctx)
+	if err != nil {
+		return err
+	}
+
+	plan, err := planWorkspaceRun(snapshot, req)
+	if err != nil {
+		return err
+	}
+
+	argv, err := domain.BuildRunCommand(snapshot.Manager, req.Script, req.ExtraArgs)
+	if err != nil {
+		return err
+	}
+
+	if req.Parallel {
+		graph := domain.NewWorkspaceGraph(snapshot.ByWorkspace)
+		return u.runParallel(ctx, graph, plan, argv, req)
+	}
+
+	for _, pkg := range plan {
+		if err := u.runner.Run(ctx, pkg.Dir, argv); err != nil {
+			return fmt.Errorf("%s: %w", pkg.WorkspaceKey, err)
+		}
+	}
+	return nil
+}
+
+// runParallel runs the plan with at most req.Concurrency commands at once. A workspace
+// starts only after every planned workspace it depends on, directly or transitively,
+// has finished successfully.
+func (u RunUseCase) runParallel(ctx context.Context, graph domain.WorkspaceGraph, plan []domain.PackageInfo, argv []string, req RunManyRequest) error {
+	if req.Concurrency < 0 {
+		return fmt.Errorf("concurrency must be at least 1")
+	}
+	limit := req.Concurrency
+	if limit == 0 {
+		limit = runtime.NumCPU()
+	}
+
+	prefix := req.Prefix
+	if prefix == nil {
+		prefix = func(workspace string, _ int) ports.StreamPrefix {
+			return ports.StreamPrefix{Stdout: workspace + " | ", Stderr: workspace + " | "}
+		}
+	}
+	width := 0
+	for _, pkg := range plan {
+		width = max(width, len(pkg.WorkspaceKey))
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	planned := make(map[string]struct{}, len(plan))
+	for _, pkg := range plan {
+		planned[pkg.WorkspaceKey] = struct{}{}
+	}
+
+	done := make(map[string]chan struct{}, len(plan))
+	failed := make(map[string]bool, len(plan))
+	for _, pkg := range plan {
+		done[pkg.WorkspaceKey] = make(chan struct{})
+	}
+
+	var mu sync.Mutex
+	var errs []error
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, limit)
+
+	for _, pkg := range plan {
+		deps := plannedDependencies(graph, pkg.WorkspaceKey, planned)
+		wg.Add(1)
+		go func(pkg domain.PackageInfo, deps []string) {
+			defer wg.Done()
+			defer close(done[pkg.WorkspaceKey])
+
+			for _, dep := range deps {
+				<-done[dep]
+			}
+
+			mu.Lock()
+			blocked := ctx.Err() != nil
+			for _, dep := range deps {
+				blocked = blocked || failed[dep]
+			}
+			if blocked {
+				failed[pkg.WorkspaceKey] = true
+			}
+			mu.Unlock()
+			if blocked {
+				return
+			}
+
+			sem <- struct{}{}
+			err := u.runStreamed(ctx, pkg, argv, prefix(pkg.WorkspaceKey, width))
+			<-sem
+
+			if err != nil {
+				mu.Lock()
+				failed[pkg.WorkspaceKey] = true
+				errs = append(errs, fmt.Errorf("%s: %w", pkg.WorkspaceKey, err))
+				mu.Unlock()
+				cancel()
+			}
+		}(pkg, deps)
+	}
+
+	wg.Wait()
+	return errors.Join(errs...)
+}
+
+func (u RunUseCase) runStreamed(ctx context.Context, pkg domain.PackageInfo, argv []string, prefix ports.StreamPrefix) error {
+	if u.streams == nil {
+		return u.runner.Run(ctx, pkg.Dir, argv)
+	}
+	return u.streams.RunPrefixed(ctx, pkg.Dir, argv, prefix)
+}
+
+// plannedDependencies returns the planned workspaces key depends on, following
+// dependencies through workspaces that are not part of the plan.
+func plannedDependencies(graph domain.WorkspaceGraph, key string, planned map[string]struct{}) []string {
+	seen := map[string]struct{}{}
+	queue := append([]string(nil), graph.Dependencies(key)...)
+	out := make([]string, 0)
+	for len(queue) > 0 {
+		next := queue[0]
+		queue = queue[1:]
+		if _, ok := seen[next]; ok {
+			continue
+		}
+		seen[next] = struct{}{}
+		if _, ok := planned[next]; ok {
+			out = append(out, next)
+		}
+		queue = append(queue, graph.Dependencies(next)...)
+	}
+	sort.Strings(out)
+	return out
+}
+
+func planWorkspaceRun(snapshot Snapshot, req RunManyRequest) ([]domain.PackageInfo, error) {
+	script := strings.TrimSpace(req.Script)
+	if script == "" {
+		return nil, fmt.Errorf("script cannot be empty")
+	}
+
+	filters := trimNonEmpty(req.Filters)
+	if !req.All && len(filters) == 0 {
+		return nil, fmt.Errorf("select workspaces with --all or --filter")
+	}
+
+	selected := map[string]struct{}{}
+	keys := []string{}
+	for key, pkg := range snapshot.ByWorkspace {
+		if req.All || matchesAnyWorkspace(filters, pkg) {
+			selected[key] = struct{}{}
+			keys = append(keys, key)
+		}
+	}
+	if len(selected) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrWorkspaceNotFound, strings.Join(filters, ", "))
+	}
+
+	order, err := domain.NewWorkspaceGraph(snapshot.ByWorkspace).Order(keys...)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := make([]domain.PackageInfo, 0, len(selected))
+	for _, key := range order {
+		if _, ok := selected[key]; !ok {
+			continue
+		}
+		pkg := snapshot.ByWorkspace[key]
+		if _, ok := pkg.Scripts[script]; !ok {
+			continue
+		}
+		plan = append(plan, pkg)
+	}
+	if len(plan) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrScriptNotFound, script)
+	}
+	return plan, nil
+}
+
+func matchesAnyWorkspace(patterns []string, pkg domain.PackageInfo) bool {
+	for _, pattern := range patterns {
+		if domain.MatchWorkspace(pattern, pkg) {
+			return true
+		}
+	}
+	return false
+}
@@ -40,6 +40,7 @@ func (u UninstallUseCase) Run(ctx context.Context, req UninstallRequest) error {
 	if _, ok := pkg.Dependencies[target.Name]; !ok {
 		return fmt.Errorf("%w: %s", ErrPackageNotFound, target.Name)
 	}
+	ReportFrom(ctx).addPackages(target.Name)
 
 	argv, err := domain.BuildUninstallCommand(snapshot.Manager, target.Name)
 	if err != nil {
@@ -40,6 +40,7 @@ func (u UpdateUseCase) Run(ctx context.Context, req UpdateRequest) error {
 	if _, ok := pkg.Dependencies[target.Name]; !ok {
 		return fmt.Errorf("%w: %s", ErrPackageNotFound, target.Name)
 	}
+	ReportFrom(ctx).addPackages(target.Name)
 
 	argv, err := domain.BuildUpdateCommand(snapshot.Manager, target.Name)
 	if err != nil {
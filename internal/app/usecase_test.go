@@ -3,9 +3,16 @@ package app
 import (
 	"context"
 	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
 	"testing"
 
+	fsadapter "ordo/internal/adapters/fs"
 	"ordo/internal/domain"
+	"ordo/internal/ports"
 )
 
 type fakeIndexer struct {
@@ -161,3 +168,332 @@ func fixtureInfos() []domain.PackageInfo {
 		},
 	}
 }
+
+type recordingRunner struct {
+	dirs []string
+	argv [][]string
+	err  error
+}
+
+func (f *recordingRunner) Run(_ context.Context, dir string, argv []string) error {
+	f.dirs = append(f.dirs, dir)
+	f.argv = append(f.argv, append([]string(nil), argv...))
+	return f.err
+}
+
+func TestRunUseCaseRunManyDependencyOrder(t *testing.T) {
+	runner := &recordingRunner{}
+	discovery := NewDiscoveryService(fakeIndexer{infos: []domain.PackageInfo{
+		{Dir: ".", Lockfiles: map[string]bool{"pnpm-lock.yaml": true}},
+		{Dir: "apps/web", Name: "@acme/web", Scripts: map[string]string{"build": "vite build"}, DependencyVersions: map[string]string{"@acme/ui": "workspace:*"}},
+		{Dir: "packages/ui", Name: "@acme/ui", Scripts: map[string]string{"build": "tsup"}, DependencyVersions: map[string]string{"@acme/config": "workspace:*"}},
+		{Dir: "packages/config", Name: "@acme/config", Scripts: map[string]string{"lint": "eslint ."}},
+	}})
+	uc := NewRunUseCase(discovery, runner)
+
+	err := uc.RunMany(context.Background(), RunManyRequest{Script: "build", All: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []string{"packages/ui", "apps/web"}
+	if len(runner.dirs) != len(want) {
+		t.Fatalf("dirs = %#v, want %#v", runner.dirs, want)
+	}
+	for i := range want {
+		if runner.dirs[i] != want[i] {
+			t.Fatalf("dirs[%d] = %q, want %q", i, runner.dirs[i], want[i])
+		}
+	}
+	if runner.argv[0][0] != "pnpm" || runner.argv[0][2] != "build" {
+		t.Fatalf("unexpected argv: %#v", runner.argv[0])
+	}
+}
+
+func TestRunUseCaseRunManyFilterIgnoresUnrelatedCycle(t *testing.T) {
+	runner := &recordingRunner{}
+	discovery := NewDiscoveryService(fakeIndexer{infos: []domain.PackageInfo{
+		{Dir: "."},
+		{Dir: "packages/a", Name: "a", Scripts: map[string]string{"build": "tsup"}},
+		{Dir: "packages/x", Name: "x", Scripts: map[string]string{"build": "tsup"}, DependencyVersions: map[string]string{"y": "workspace:*"}},
+		{Dir: "packages/y", Name: "y", Scripts: map[string]string{"build": "tsup"}, DependencyVersions: map[string]string{"x": "workspace:*"}},
+	}})
+	uc := NewRunUseCase(discovery, runner)
+
+	if err := uc.RunMany(context.Background(), RunManyRequest{Script: "build", Filters: []string{"a"}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(runner.dirs) != 1 || runner.dirs[0] != "packages/a" {
+		t.Fatalf("dirs = %#v", runner.dirs)
+	}
+
+	err := uc.RunMany(context.Background(), RunManyRequest{Script: "build", All: true})
+	if !errors.Is(err, domain.ErrDependencyCycle) {
+		t.Fatalf("expected ErrDependencyCycle, got %v", err)
+	}
+}
+
+func TestRunUseCaseRunManyFilter(t *testing.T) {
+	runner := &recordingRunner{}
+	discovery := NewDiscoveryService(fakeIndexer{infos: []domain.PackageInfo{
+		{Dir: "."},
+		{Dir: "apps/web", Scripts: map[string]string{"build": "vite build"}},
+		{Dir: "packages/ui", Scripts: map[string]string{"build": "tsup"}},
+	}})
+	uc := NewRunUseCase(discovery, runner)
+
+	err := uc.RunMany(context.Background(), RunManyRequest{Script: "build", Filters: []string{"packages/*"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(runner.dirs) != 1 || runner.dirs[0] != "packages/ui" {
+		t.Fatalf("dirs = %#v", runner.dirs)
+	}
+
+	err = uc.RunMany(context.Background(), RunManyRequest{Script: "test", All: true})
+	if !errors.Is(err, ErrScriptNotFound) {
+		t.Fatalf("expected ErrScriptNotFound, got %v", err)
+	}
+
+	err = uc.RunMany(context.Background(), RunManyRequest{Script: "build", Filters: []string{"nope"}})
+	if !errors.Is(err, ErrWorkspaceNotFound) {
+		t.Fatalf("expected ErrWorkspaceNotFound, got %v", err)
+	}
+}
+
+type fakeStreamRunner struct {
+	mu       sync.Mutex
+	dirs     []string
+	prefixes []string
+	fail     map[string]error
+}
+
+func (f *fakeStreamRunner) RunPrefixed(_ context.Context, dir string, _ []string, prefix ports.StreamPrefix) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.dirs = append(f.dirs, dir)
+	f.prefixes = append(f.prefixes, prefix.Stdout)
+	return f.fail[dir]
+}
+
+func parallelFixture() []domain.PackageInfo {
+	return []domain.PackageInfo{
+		{Dir: ".", Lockfiles: map[string]bool{"pnpm-lock.yaml": true}},
+		{Dir: "apps/web", Name: "web", Scripts: map[string]string{"build": "vite build"}, DependencyVersions: map[string]string{"ui": "workspace:*"}},
+		{Dir: "packages/ui", Name: "ui", Scripts: map[string]string{"build": "tsup"}, DependencyVersions: map[string]string{"config": "workspace:*"}},
+		{Dir: "packages/config", Name: "config", DependencyVersions: map[string]string{"tokens": "workspace:*"}},
+		{Dir: "packages/tokens", Name: "tokens", Scripts: map[string]string{"build": "tsc"}},
+	}
+}
+
+func TestRunUseCaseRunManyParallelRespectsDependencies(t *testing.T) {
+	streams := &fakeStreamRunner{}
+	discovery := NewDiscoveryService(fakeIndexer{infos: parallelFixture()})
+	uc := NewRunUseCaseWithStreams(discovery, &fakeRunner{}, streams)
+
+	err := uc.RunMany(context.Background(), RunManyRequest{
+		Script:      "build",
+		All:         true,
+		Parallel:    true,
+		Concurrency: 2,
+		Prefix: func(workspace string, width int) ports.StreamPrefix {
+			label := fmt.Sprintf("%-*s|", width, workspace)
+			return ports.StreamPrefix{Stdout: label, Stderr: label}
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []string{"packages/tokens", "packages/ui", "apps/web"}
+	if len(streams.dirs) != len(want) {
+		t.Fatalf("dirs = %#v, want %#v", streams.dirs, want)
+	}
+	for i := range want {
+		if streams.dirs[i] != want[i] {
+			t.Fatalf("dirs[%d] = %q, want %q", i, streams.dirs[i], want[i])
+		}
+	}
+	if streams.prefixes[0] != "tokens|" || streams.prefixes[2] != "web   |" {
+		t.Fatalf("unexpected prefixes: %#v", streams.prefixes)
+	}
+}
+
+func TestRunUseCaseRunManyParallelSkipsDependentsOnFailure(t *testing.T) {
+	streams := &fakeStreamRunner{fail: map[string]error{"packages/ui": errors.New("boom")}}
+	discovery := NewDiscoveryService(fakeIndexer{infos: parallelFixture()})
+	uc := NewRunUseCaseWithStreams(discovery, &fakeRunner{}, streams)
+
+	err := uc.RunMany(context.Background(), RunManyRequest{Script: "build", All: true, Parallel: true})
+	if err == nil || !strings.Contains(err.Error(), "ui: boom") {
+		t.Fatalf("error = %v, want ui failure", err)
+	}
+	for _, dir := range streams.dirs {
+		if dir == "apps/web" {
+			t.Fatalf("dependent workspace ran after failure: %#v", streams.dirs)
+		}
+	}
+}
+
+func TestRunUseCaseDefaultsToCurrentWorkspace(t *testing.T) {
+	runner := &fakeRunner{}
+	discovery := NewDiscoveryService(fakeIndexer{infos: fixtureInfos()})
+	uc := NewRunUseCase(discovery, runner)
+	ctx := WithInvocationDir(context.Background(), "packages/ui/src")
+
+	if err := uc.Run(ctx, RunRequest{Target: "build"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if runner.dir != "packages/ui" {
+		t.Fatalf("expected current workspace dir packages/ui, got %s", runner.dir)
+	}
+
+	if err := uc.Run(ctx, RunRequest{Target: "./build"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if runner.dir != "." {
+		t.Fatalf("expected explicit root dir '.', got %s", runner.dir)
+	}
+}
+
+func TestRunUseCaseResolvesPackageName(t *testing.T) {
+	runner := &fakeRunner{}
+	discovery := NewDiscoveryService(fakeIndexer{infos: []domain.PackageInfo{
+		{Dir: "."},
+		{Dir: "apps/web", Name: "@acme/web", Scripts: map[string]string{"build:prod": "vite build"}},
+	}})
+	uc := NewRunUseCase(discovery, runner)
+
+	if err := uc.Run(context.Background(), RunRequest{Target: "@acme/web#build:prod"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if runner.dir != "apps/web" {
+		t.Fatalf("expected runner dir apps/web, got %s", runner.dir)
+	}
+
+	snapshot, err := discovery.Snapshot(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	targets := snapshot.ScriptTargets("")
+	want := []string{"@acme/web#build:prod", "web/build:prod"}
+	if len(targets) != len(want) || targets[0] != want[0] || targets[1] != want[1] {
+		t.Fatalf("ScriptTargets() = %#v, want %#v", targets, want)
+	}
+}
+
+type fakeNotifier struct {
+	warnings []string
+}
+
+func (f *fakeNotifier) Warn(message string) {
+	f.warnings = append(f.warnings, message)
+}
+
+func TestSnapshotManagerFromConfigDefaults(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	projectDir := t.TempDir()
+	store := fsadapter.NewConfigStore()
+
+	discovery := NewDiscoveryServiceWithConfig(fakeIndexer{infos: []domain.PackageInfo{{Dir: "."}}}, store, projectDir, nil)
+	userConfig := filepath.Join(os.Getenv("XDG_CONFIG_HOME"), "ordo", "ordo.json")
+	if err := os.MkdirAll(filepath.Dir(userConfig), 0o755); err != nil {
+		t.Fatalf("MkdirAll() error = %v", err)
+	}
+	if err := os.WriteFile(userConfig, []byte(`{"defaultPackageManager":"yarn"}`), 0o644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	snapshot, err := discovery.Snapshot(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if snapshot.Manager != domain.ManagerYarn {
+		t.Fatalf("manager = %s, want yarn from user config", snapshot.Manager)
+	}
+
+	if err := os.WriteFile(filepath.Join(projectDir, "ordo.json"), []byte(`{"defaultPackageManager":"bun"}`), 0o644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	snapshot, err = discovery.Snapshot(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if snapshot.Manager != domain.ManagerBun {
+		t.Fatalf("manager = %s, want bun from project config", snapshot.Manager)
+	}
+}
+
+func TestSnapshotManagerFromPackageManagerFieldWarnsOnMixedLockfiles(t *testing.T) {
+	notifier := &fakeNotifier{}
+	discovery := NewDiscoveryServiceWithConfig(fakeIndexer{infos: []domain.PackageInfo{{
+		Dir:            ".",
+		PackageManager: "pnpm@9.12.0",
+		Lockfiles:      map[string]bool{"pnpm-lock.yaml": true, "yarn.lock": true},
+	}}}, nil, "", notifier)
+
+	report := &Report{}
+	snapshot, err := discovery.Snapshot(WithReport(context.Background(), report))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if snapshot.Manager != domain.ManagerPNPM || report.ManagerVersion() != "9.12.0" {
+		t.Fatalf("manager = %s@%s, want pnpm@9.12.0", snapshot.Manager, report.ManagerVersion())
+	}
+	if len(notifier.warnings) != 1 || !strings.Contains(notifier.warnings[0], "pnpm-lock.yaml, yarn.lock") {
+		t.Fatalf("warnings = %#v", notifier.warnings)
+	}
+}
+
+func TestSnapshotRefusesAmbiguousLockfiles(t *testing.T) {
+	discovery := NewDiscoveryService(fakeIndexer{infos: []domain.PackageInfo{{
+		Dir:       ".",
+		Lockfiles: map[string]bool{"pnpm-lock.yaml": true, "yarn.lock": true},
+	}}})
+
+	_, err := discovery.Snapshot(context.Background())
+	if !errors.Is(err, ErrAmbiguousManager) {
+		t.Fatalf("expected ErrAmbiguousManager, got %v", err)
+	}
+	var ambiguous AmbiguousManagerError
+	if !errors.As(err, &ambiguous) || strings.Join(ambiguous.Lockfiles, ",") != "pnpm-lock.yaml,yarn.lock" {
+		t.Fatalf("unexpected lockfiles: %#v", ambiguous.Lockfiles)
+	}
+}
+
+func TestSnapshotManagerOverrideResolvesAmbiguousLockfiles(t *testing.T) {
+	notifier := &fakeNotifier{}
+	discovery := NewDiscoveryServiceWithConfig(fakeIndexer{infos: []domain.PackageInfo{{
+		Dir:            ".",
+		PackageManager: "pnpm@9.12.0",
+		Lockfiles:      map[string]bool{"pnpm-lock.yaml": true, "yarn.lock": true},
+	}}}, nil, "", notifier)
+
+	snapshot, err := discovery.Snapshot(WithManagerOverride(context.Background(), domain.ManagerYarn))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if snapshot.Manager != domain.ManagerYarn {
+		t.Fatalf("manager = %s, want yarn", snapshot.Manager)
+	}
+	if len(notifier.warnings) != 1 {
+		t.Fatalf("warnings = %#v", notifier.warnings)
+	}
+}
+
+func TestInstallUseCaseFillsReport(t *testing.T) {
+	runner := &fakeRunner{}
+	discovery := NewDiscoveryService(fakeIndexer{infos: fixtureInfos()})
+	uc := NewInstallUseCase(discovery, runner)
+
+	report := &Report{}
+	err := uc.Run(WithReport(context.Background(), report), InstallRequest{Packages: []string{"zod", " ", "react"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if report.Manager() != domain.ManagerPNPM {
+		t.Fatalf("manager = %s, want pnpm", report.Manager())
+	}
+	if got := strings.Join(report.Packages(), ","); got != "zod,react" {
+		t.Fatalf("packages = %s, want zod,react", got)
+	}
+}
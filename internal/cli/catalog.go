@@ -1,9 +1,13 @@
 package cli
 
 import (
+	"fmt"
+	"strings"
+
 	"ordo/internal/app"
 	"ordo/internal/cli/completion"
 	"ordo/internal/cli/output"
+	"ordo/internal/domain"
 
 	"github.com/spf13/cobra"
 )
@@ -20,10 +24,18 @@ func newCatalogCmd(
 	}
 
 	cmd.AddCommand(newCatalogAddCmd(uc, catalogCompleter, printer))
+	cmd.AddCommand(newCatalogCheckCmd(uc, printer))
+	cmd.AddCommand(newCatalogConsolidateCmd(uc, catalogCompleter, printer))
 	cmd.AddCommand(newCatalogImportCmd(uc, catalogCompleter, printer))
+	cmd.AddCommand(newCatalogInlineCmd(uc, catalogCompleter, printer))
+	cmd.AddCommand(newCatalogListCmd(uc, printer))
+	cmd.AddCommand(newCatalogMigrateCmd(uc, printer))
+	cmd.AddCommand(newCatalogOutdatedCmd(uc, printer))
 	cmd.AddCommand(newCatalogPresetsCmd(uc, catalogCompleter, presetCompleter, printer))
+	cmd.AddCommand(newCatalogPruneCmd(uc, printer))
 	cmd.AddCommand(newCatalogRemoveCmd(uc, catalogCompleter, printer))
 	cmd.AddCommand(newCatalogSyncCmd(uc, printer))
+	cmd.AddCommand(newCatalogUpgradeCmd(uc, catalogCompleter, printer))
 
 	return cmd
 }
@@ -52,7 +64,7 @@ func newCatalogImportCmd(uc app.CatalogUseCase, completer completion.CatalogComp
 				FromWorkspace: fromWorkspace,
 				Force:         force,
 			})
-			return printer.Handle(cmd.ErrOrStderr(), err)
+			return printer.Handle(cmd, err)
 		},
 	}
 
@@ -70,6 +82,89 @@ func newCatalogImportCmd(uc app.CatalogUseCase, completer completion.CatalogComp
 	return cmd
 }
 
+func newCatalogCheckCmd(uc app.CatalogUseCase, printer output.Printer) *cobra.Command {
+	return &cobra.Command{
+		Use:   "check",
+		Short: "Fail when workspace dependencies break catalog references",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			violations, err := uc.RunCheck(cmd.Context())
+			if err == nil {
+				err = printer.CatalogCheck(cmd, violations)
+			}
+			if err == nil && len(violations) > 0 {
+				err = fmt.Errorf("%w: %d violation(s)", app.ErrCatalogCheckFailed, len(violations))
+			}
+			return printer.Handle(cmd, err)
+		},
+	}
+}
+
+func newCatalogConsolidateCmd(uc app.CatalogUseCase, completer completion.CatalogCompleter, printer output.Printer) *cobra.Command {
+	var interactive bool
+	var force bool
+
+	cmd := &cobra.Command{
+		Use:   "consolidate [pkg]...",
+		Short: "Move dependencies with differing ranges across workspaces into the default catalog",
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			items, err := completer.DriftingDependencyNames(cmd.Context(), toComplete)
+			if err != nil {
+				return nil, cobra.ShellCompDirectiveError
+			}
+			items = filterCompletedArgs(items, args, 0)
+			return items, cobra.ShellCompDirectiveNoFileComp
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			req := app.CatalogConsolidateRequest{Packages: args, Force: force}
+			if interactive {
+				req.Chooser = newRangePrompt(cmd.InOrStdin(), cmd.ErrOrStderr())
+			}
+			results, err := uc.RunConsolidate(cmd.Context(), req)
+			if err == nil {
+				err = printer.CatalogConsolidate(cmd, results)
+			}
+			return printer.Handle(cmd, err)
+		},
+	}
+
+	cmd.Flags().BoolVarP(&interactive, "interactive", "i", false, "Choose each package's range instead of taking the highest")
+	cmd.Flags().BoolVar(&force, "force", false, "Override conflicting existing catalog versions")
+
+	return cmd
+}
+
+func newCatalogInlineCmd(uc app.CatalogUseCase, completer completion.CatalogCompleter, printer output.Printer) *cobra.Command {
+	var remove bool
+
+	cmd := &cobra.Command{
+		Use:   "inline [pkg]...",
+		Short: "Replace catalog references in every workspace with the catalog's version",
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			items, err := completer.CatalogPackageNames(cmd.Context(), "", toComplete)
+			if err != nil {
+				return nil, cobra.ShellCompDirectiveError
+			}
+			items = filterCompletedArgs(items, args, 0)
+			return items, cobra.ShellCompDirectiveNoFileComp
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			results, err := uc.RunInline(cmd.Context(), app.CatalogInlineRequest{
+				Packages: args,
+				Remove:   remove,
+			})
+			if err == nil {
+				err = printer.CatalogInline(cmd, results)
+			}
+			return printer.Handle(cmd, err)
+		},
+	}
+
+	cmd.Flags().BoolVar(&remove, "remove", false, "Remove the inlined entries from their catalogs")
+
+	return cmd
+}
+
 func newCatalogAddCmd(uc app.CatalogUseCase, completer completion.CatalogCompleter, printer output.Printer) *cobra.Command {
 	var workspace string
 	var force bool
@@ -92,11 +187,11 @@ func newCatalogAddCmd(uc app.CatalogUseCase, completer completion.CatalogComplet
 				Workspace: workspace,
 				Force:     force,
 			})
-			return printer.Handle(cmd.ErrOrStderr(), err)
+			return printer.Handle(cmd, err)
 		},
 	}
 
-	cmd.Flags().StringVar(&workspace, "workspace", "", "Workspace key to apply dependency references (default: root)")
+	cmd.Flags().StringVar(&workspace, "workspace", "", "Workspace key to apply dependency references (default: current workspace, else root)")
 	cmd.Flags().BoolVar(&force, "force", false, "Override conflicting existing catalog versions")
 	mustRegisterFlagCompletionFunc(cmd, "workspace", func(cmd *cobra.Command, _ []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 		items, err := completer.WorkspaceKeys(cmd.Context(), toComplete)
@@ -124,21 +219,153 @@ func newCatalogRemoveCmd(uc app.CatalogUseCase, completer completion.CatalogComp
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			err := uc.RunRemove(cmd.Context(), app.CatalogRemoveRequest{Packages: args})
-			return printer.Handle(cmd.ErrOrStderr(), err)
+			return printer.Handle(cmd, err)
 		},
 	}
 }
 
-func newCatalogSyncCmd(uc app.CatalogUseCase, printer output.Printer) *cobra.Command {
+func newCatalogListCmd(uc app.CatalogUseCase, printer output.Printer) *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List the default and named catalogs with the workspaces referencing each entry",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			listings, err := uc.RunList(cmd.Context(), app.CatalogListRequest{})
+			if err == nil {
+				err = printer.Catalogs(cmd, listings)
+			}
+			return printer.Handle(cmd, err)
+		},
+	}
+}
+
+func newCatalogMigrateCmd(uc app.CatalogUseCase, printer output.Printer) *cobra.Command {
+	var to string
+	var force bool
+
+	cmd := &cobra.Command{
+		Use:   "migrate --to <manager>",
+		Short: "Move all catalogs to another package manager's catalog file",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			manager, err := domain.ParsePackageManager(to)
+			if err != nil {
+				return printer.Handle(cmd, err)
+			}
+			migration, err := uc.RunMigrate(cmd.Context(), app.CatalogMigrateRequest{To: manager, Force: force})
+			if err == nil {
+				err = printer.CatalogMigrate(cmd, migration)
+			}
+			return printer.Handle(cmd, err)
+		},
+	}
+
+	cmd.Flags().StringVar(&to, "to", "", "Package manager to move catalogs to (pnpm, yarn, or bun)")
+	cmd.Flags().BoolVar(&force, "force", false, "Override conflicting versions already in the target catalogs")
+	mustMarkFlagRequired(cmd, "to")
+	mustRegisterFlagCompletionFunc(cmd, "to", func(_ *cobra.Command, _ []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		items := []string{}
+		for _, manager := range domain.SupportedPackageManagers() {
+			if domain.SupportsCatalogs(domain.PackageManager(manager)) && strings.HasPrefix(manager, toComplete) {
+				items = append(items, manager)
+			}
+		}
+		return items, cobra.ShellCompDirectiveNoFileComp
+	})
+
+	return cmd
+}
+
+func newCatalogOutdatedCmd(uc app.CatalogUseCase, printer output.Printer) *cobra.Command {
 	return &cobra.Command{
+		Use:   "outdated",
+		Short: "Compare default and named catalog entries against the registry",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			entries, err := uc.RunOutdated(cmd.Context(), app.CatalogOutdatedRequest{})
+			if err == nil {
+				err = printer.CatalogOutdated(cmd, entries)
+			}
+			return printer.Handle(cmd, err)
+		},
+	}
+}
+
+func newCatalogUpgradeCmd(uc app.CatalogUseCase, completer completion.CatalogCompleter, printer output.Printer) *cobra.Command {
+	var major, minor, patch bool
+
+	cmd := &cobra.Command{
+		Use:   "upgrade [pkg]...",
+		Short: "Upgrade catalog entries to newer registry versions, keeping their range operator",
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			items, err := completer.CatalogPackageNames(cmd.Context(), "", toComplete)
+			if err != nil {
+				return nil, cobra.ShellCompDirectiveError
+			}
+			items = filterCompletedArgs(items, args, 0)
+			return items, cobra.ShellCompDirectiveNoFileComp
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			level := domain.UpgradeMajor
+			switch {
+			case minor:
+				level = domain.UpgradeMinor
+			case patch:
+				level = domain.UpgradePatch
+			}
+			upgrades, err := uc.RunUpgrade(cmd.Context(), app.CatalogUpgradeRequest{
+				Packages: args,
+				Level:    level,
+			})
+			if err == nil {
+				err = printer.CatalogUpgrade(cmd, upgrades)
+			}
+			return printer.Handle(cmd, err)
+		},
+	}
+
+	cmd.Flags().BoolVar(&major, "major", false, "Allow upgrades up to the latest version (default)")
+	cmd.Flags().BoolVar(&minor, "minor", false, "Only upgrade within the current major version")
+	cmd.Flags().BoolVar(&patch, "patch", false, "Only upgrade within the current minor version")
+	cmd.MarkFlagsMutuallyExclusive("major", "minor", "patch")
+
+	return cmd
+}
+
+func newCatalogPruneCmd(uc app.CatalogUseCase, printer output.Printer) *cobra.Command {
+	return &cobra.Command{
+		Use:   "prune",
+		Short: "Remove default catalog entries that no workspace references",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			pruned, err := uc.RunPrune(cmd.Context(), app.CatalogPruneRequest{Names: []string{""}})
+			if err == nil {
+				err = printer.CatalogPrune(cmd, pruned)
+			}
+			return printer.Handle(cmd, err)
+		},
+	}
+}
+
+func newCatalogSyncCmd(uc app.CatalogUseCase, printer output.Printer) *cobra.Command {
+	var all bool
+
+	cmd := &cobra.Command{
 		Use:   "sync",
 		Short: "Sync root catalog references to all nested workspaces",
 		Args:  cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, _ []string) error {
-			err := uc.RunSync(cmd.Context(), app.CatalogSyncRequest{})
-			return printer.Handle(cmd.ErrOrStderr(), err)
+			result, err := uc.RunSync(cmd.Context(), app.CatalogSyncRequest{All: all})
+			if err == nil {
+				err = printer.CatalogSync(cmd, result)
+			}
+			return printer.Handle(cmd, err)
 		},
 	}
+
+	cmd.Flags().BoolVar(&all, "all", false, "Sync named catalogs as well as the default catalog")
+
+	return cmd
 }
 
 func newCatalogPresetsCmd(
@@ -185,11 +412,11 @@ func newCatalogPresetsCmd(
 				Workspace: workspace,
 				Force:     force,
 			})
-			return printer.Handle(cmd.ErrOrStderr(), err)
+			return printer.Handle(cmd, err)
 		},
 	}
 
-	cmd.Flags().StringVar(&workspace, "workspace", "", "Workspace key to apply dependency references (default: root)")
+	cmd.Flags().StringVar(&workspace, "workspace", "", "Workspace key to apply dependency references (default: current workspace, else root)")
 	cmd.Flags().BoolVar(&force, "force", false, "Override conflicting existing catalog versions")
 	mustRegisterFlagCompletionFunc(cmd, "workspace", func(cmd *cobra.Command, _ []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 		items, err := catalogCompleter.WorkspaceKeys(cmd.Context(), toComplete)
@@ -15,7 +15,13 @@ func newCatalogsCmd(uc app.CatalogUseCase, completer completion.CatalogCompleter
 	}
 
 	cmd.AddCommand(newCatalogsAddCmd(uc, completer, printer))
+	cmd.AddCommand(newCatalogsCopyCmd(uc, completer, printer))
+	cmd.AddCommand(newCatalogsDeleteCmd(uc, completer, printer))
+	cmd.AddCommand(newCatalogsListCmd(uc, completer, printer))
+	cmd.AddCommand(newCatalogsPruneCmd(uc, completer, printer))
 	cmd.AddCommand(newCatalogsRemoveCmd(uc, completer, printer))
+	cmd.AddCommand(newCatalogsRenameCmd(uc, completer, printer))
+	cmd.AddCommand(newCatalogsSyncCmd(uc, completer, printer))
 
 	return cmd
 }
@@ -51,11 +57,11 @@ func newCatalogsAddCmd(uc app.CatalogUseCase, completer completion.CatalogComple
 				Workspace: workspace,
 				Force:     force,
 			})
-			return printer.Handle(cmd.ErrOrStderr(), err)
+			return printer.Handle(cmd, err)
 		},
 	}
 
-	cmd.Flags().StringVar(&workspace, "workspace", "", "Workspace key to apply dependency references (default: root)")
+	cmd.Flags().StringVar(&workspace, "workspace", "", "Workspace key to apply dependency references (default: current workspace, else root)")
 	cmd.Flags().BoolVar(&force, "force", false, "Override conflicting existing catalog versions")
 	mustRegisterFlagCompletionFunc(cmd, "workspace", func(cmd *cobra.Command, _ []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 		items, err := completer.WorkspaceKeys(cmd.Context(), toComplete)
@@ -68,6 +74,94 @@ func newCatalogsAddCmd(uc app.CatalogUseCase, completer completion.CatalogComple
 	return cmd
 }
 
+func newCatalogsCopyCmd(uc app.CatalogUseCase, completer completion.CatalogCompleter, printer output.Printer) *cobra.Command {
+	return &cobra.Command{
+		Use:               "copy <name> <new-name>",
+		Short:             "Copy a named catalog under a new name",
+		Args:              cobra.ExactArgs(2),
+		ValidArgsFunction: firstArgNamedCatalog(completer),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			copied, err := uc.RunCopy(cmd.Context(), app.CatalogCopyRequest{From: args[0], To: args[1]})
+			if err == nil {
+				err = printer.CatalogCopy(cmd, copied)
+			}
+			return printer.Handle(cmd, err)
+		},
+	}
+}
+
+func newCatalogsDeleteCmd(uc app.CatalogUseCase, completer completion.CatalogCompleter, printer output.Printer) *cobra.Command {
+	var force bool
+
+	cmd := &cobra.Command{
+		Use:               "delete <name>",
+		Short:             "Delete a named catalog",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: firstArgNamedCatalog(completer),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			deletion, err := uc.RunDelete(cmd.Context(), app.CatalogDeleteRequest{Name: args[0], Force: force})
+			if err == nil {
+				err = printer.CatalogDelete(cmd, deletion)
+			}
+			return printer.Handle(cmd, err)
+		},
+	}
+
+	cmd.Flags().BoolVar(&force, "force", false, "Inline remaining catalog references before deleting")
+
+	return cmd
+}
+
+func newCatalogsListCmd(uc app.CatalogUseCase, completer completion.CatalogCompleter, printer output.Printer) *cobra.Command {
+	return &cobra.Command{
+		Use:   "list [name]...",
+		Short: "List named catalogs with the workspaces referencing each entry",
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			items, err := completer.NamedCatalogs(cmd.Context(), toComplete)
+			if err != nil {
+				return nil, cobra.ShellCompDirectiveError
+			}
+			items = filterCompletedArgs(items, args, 0)
+			return items, cobra.ShellCompDirectiveNoFileComp
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			listings, err := uc.RunList(cmd.Context(), app.CatalogListRequest{
+				Names:     args,
+				NamedOnly: true,
+			})
+			if err == nil {
+				err = printer.Catalogs(cmd, listings)
+			}
+			return printer.Handle(cmd, err)
+		},
+	}
+}
+
+func newCatalogsPruneCmd(uc app.CatalogUseCase, completer completion.CatalogCompleter, printer output.Printer) *cobra.Command {
+	return &cobra.Command{
+		Use:   "prune [name]...",
+		Short: "Remove named catalog entries that no workspace references",
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			items, err := completer.NamedCatalogs(cmd.Context(), toComplete)
+			if err != nil {
+				return nil, cobra.ShellCompDirectiveError
+			}
+			items = filterCompletedArgs(items, args, 0)
+			return items, cobra.ShellCompDirectiveNoFileComp
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			pruned, err := uc.RunPrune(cmd.Context(), app.CatalogPruneRequest{
+				Names:     args,
+				NamedOnly: true,
+			})
+			if err == nil {
+				err = printer.CatalogPrune(cmd, pruned)
+			}
+			return printer.Handle(cmd, err)
+		},
+	}
+}
+
 func newCatalogsRemoveCmd(uc app.CatalogUseCase, completer completion.CatalogCompleter, printer output.Printer) *cobra.Command {
 	return &cobra.Command{
 		Use:   "remove <name> <pkg>...",
@@ -94,7 +188,60 @@ func newCatalogsRemoveCmd(uc app.CatalogUseCase, completer completion.CatalogCom
 				Name:     args[0],
 				Packages: args[1:],
 			})
-			return printer.Handle(cmd.ErrOrStderr(), err)
+			return printer.Handle(cmd, err)
 		},
 	}
 }
+
+func newCatalogsRenameCmd(uc app.CatalogUseCase, completer completion.CatalogCompleter, printer output.Printer) *cobra.Command {
+	return &cobra.Command{
+		Use:               "rename <name> <new-name>",
+		Short:             "Rename a named catalog and rewrite its references",
+		Args:              cobra.ExactArgs(2),
+		ValidArgsFunction: firstArgNamedCatalog(completer),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			rename, err := uc.RunRename(cmd.Context(), app.CatalogRenameRequest{From: args[0], To: args[1]})
+			if err == nil {
+				err = printer.CatalogRename(cmd, rename)
+			}
+			return printer.Handle(cmd, err)
+		},
+	}
+}
+
+func newCatalogsSyncCmd(uc app.CatalogUseCase, completer completion.CatalogCompleter, printer output.Printer) *cobra.Command {
+	return &cobra.Command{
+		Use:   "sync <name>...",
+		Short: "Sync named catalog references to all nested workspaces",
+		Args:  cobra.MinimumNArgs(1),
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			items, err := completer.NamedCatalogs(cmd.Context(), toComplete)
+			if err != nil {
+				return nil, cobra.ShellCompDirectiveError
+			}
+			items = filterCompletedArgs(items, args, 0)
+			return items, cobra.ShellCompDirectiveNoFileComp
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			result, err := uc.RunSync(cmd.Context(), app.CatalogSyncRequest{Names: args})
+			if err == nil {
+				err = printer.CatalogSync(cmd, result)
+			}
+			return printer.Handle(cmd, err)
+		},
+	}
+}
+
+// firstArgNamedCatalog completes named catalogs for the first argument only.
+func firstArgNamedCatalog(completer completion.CatalogCompleter) func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) > 0 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		items, err := completer.NamedCatalogs(cmd.Context(), toComplete)
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveError
+		}
+		return items, cobra.ShellCompDirectiveNoFileComp
+	}
+}
@@ -33,3 +33,7 @@ func (c CatalogCompleter) CatalogPackageNames(ctx context.Context, name string,
 func (c CatalogCompleter) WorkspaceDependencyNames(ctx context.Context, workspace string, prefix string) ([]string, error) {
 	return c.catalogs.WorkspaceDependencyNames(ctx, workspace, prefix)
 }
+
+func (c CatalogCompleter) DriftingDependencyNames(ctx context.Context, prefix string) ([]string, error) {
+	return c.catalogs.DriftingDependencyNames(ctx, prefix)
+}
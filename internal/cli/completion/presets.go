@@ -22,6 +22,10 @@ func (c PresetCompleter) Buckets(ctx context.Context, preset string, prefix stri
 	return c.presets.Buckets(ctx, preset, prefix)
 }
 
+func (c PresetCompleter) SupportedBuckets(ctx context.Context, prefix string) ([]string, error) {
+	return c.presets.SupportedBuckets(ctx, prefix)
+}
+
 func (c PresetCompleter) BucketPackages(ctx context.Context, preset string, bucket string, prefix string) ([]string, error) {
 	return c.presets.BucketPackages(ctx, preset, bucket, prefix)
 }
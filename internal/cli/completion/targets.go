@@ -38,3 +38,11 @@ func (c TargetCompleter) WorkspaceKeys(ctx context.Context, prefix string) ([]st
 func (c TargetCompleter) InstallPackages(ctx context.Context, prefix string) ([]string, error) {
 	return c.installCompleter.PackageSpecs(ctx, prefix)
 }
+
+func (c TargetCompleter) WorkspaceScripts(ctx context.Context, prefix string) ([]string, error) {
+	snapshot, err := c.discovery.Snapshot(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return snapshot.WorkspaceScriptNames(prefix), nil
+}
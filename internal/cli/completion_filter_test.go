@@ -175,7 +175,7 @@ func TestPresetCompletionFiltersAlreadySelectedPackage(t *testing.T) {
 
 	cfg := []byte(`{"presets":{"web":{"devDependencies":["eslint","prettier","typescript"]}}}`)
 	presetCompleter := completion.NewPresetCompleter(app.NewPresetCompletionService(testConfigStore{payload: cfg}))
-	cmd := newPresetCmd(app.PresetUseCase{}, presetCompleter, completion.TargetCompleter{}, output.NewPrinter())
+	cmd := newPresetCmd(app.PresetUseCase{}, app.PresetConfigUseCase{}, presetCompleter, completion.TargetCompleter{}, output.NewPrinter())
 
 	items, dir := cmd.ValidArgsFunction(cmd, []string{"web", "devDependencies", "prettier"}, "")
 	if dir != cobra.ShellCompDirectiveNoFileComp {
@@ -198,7 +198,7 @@ func TestPresetCompletionPresetAndBucketAreUnchanged(t *testing.T) {
 
 	cfg := []byte(`{"presets":{"web":{"dependencies":["react"],"devDependencies":["prettier"]}}}`)
 	presetCompleter := completion.NewPresetCompleter(app.NewPresetCompletionService(testConfigStore{payload: cfg}))
-	cmd := newPresetCmd(app.PresetUseCase{}, presetCompleter, completion.TargetCompleter{}, output.NewPrinter())
+	cmd := newPresetCmd(app.PresetUseCase{}, app.PresetConfigUseCase{}, presetCompleter, completion.TargetCompleter{}, output.NewPrinter())
 
 	presets, dir := cmd.ValidArgsFunction(cmd, []string{}, "")
 	if dir != cobra.ShellCompDirectiveNoFileComp {
@@ -480,6 +480,14 @@ func (s testCatalogStore) RemoveCatalogEntries(context.Context, domain.PackageMa
 	return nil
 }
 
+func (s testCatalogStore) RenameCatalog(context.Context, domain.PackageManager, string, string) error {
+	return nil
+}
+
+func (s testCatalogStore) DeleteCatalog(context.Context, domain.PackageManager, string) error {
+	return nil
+}
+
 func (s testCatalogStore) NamedCatalogs(context.Context, domain.PackageManager) ([]string, error) {
 	return nil, nil
 }
@@ -488,6 +496,10 @@ func (s testCatalogStore) CatalogPackageNames(context.Context, domain.PackageMan
 	return nil, nil
 }
 
+func (s testCatalogStore) CatalogEntries(context.Context, domain.PackageManager, string) (map[string]string, error) {
+	return nil, nil
+}
+
 type testConfigStore struct {
 	payload []byte
 }
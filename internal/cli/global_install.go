@@ -35,14 +35,14 @@ func newGlobalInstallCmd(uc app.GlobalInstallUseCase, completer completion.Globa
 		RunE: func(cmd *cobra.Command, args []string) error {
 			manager, err := domain.ParsePackageManager(args[0])
 			if err != nil {
-				return printer.Handle(cmd.ErrOrStderr(), err)
+				return printer.Handle(cmd, err)
 			}
 
 			err = uc.Run(cmd.Context(), app.GlobalInstallRequest{
 				Manager:  manager,
 				Packages: args[1:],
 			})
-			return printer.Handle(cmd.ErrOrStderr(), err)
+			return printer.Handle(cmd, err)
 		},
 	}
 }
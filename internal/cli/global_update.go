@@ -37,14 +37,14 @@ func newGlobalUpdateCmd(uc app.GlobalUpdateUseCase, completer completion.GlobalC
 		RunE: func(cmd *cobra.Command, args []string) error {
 			manager, err := domain.ParsePackageManager(args[0])
 			if err != nil {
-				return printer.Handle(cmd.ErrOrStderr(), err)
+				return printer.Handle(cmd, err)
 			}
 
 			err = uc.Run(cmd.Context(), app.GlobalUpdateRequest{
 				Manager:  manager,
 				Packages: args[1:],
 			})
-			return printer.Handle(cmd.ErrOrStderr(), err)
+			return printer.Handle(cmd, err)
 		},
 	}
 }
@@ -16,7 +16,7 @@ func newInitCmd(uc app.InitUseCase, completer completion.GlobalCompleter, printe
 		Short: "Create ordo config in XDG config home",
 		RunE: func(cmd *cobra.Command, _ []string) error {
 			err := uc.Run(cmd.Context(), app.InitRequest{DefaultPackageManager: defaultPackageManager})
-			return printer.Handle(cmd.ErrOrStderr(), err)
+			return printer.Handle(cmd, err)
 		},
 	}
 
@@ -38,11 +38,11 @@ func newInstallCmd(uc app.InstallUseCase, completer completion.TargetCompleter,
 				Prod:      prod,
 				Exact:     exact,
 			})
-			return printer.Handle(cmd.ErrOrStderr(), err)
+			return printer.Handle(cmd, err)
 		},
 	}
 
-	cmd.Flags().StringVar(&workspace, "workspace", "", "Workspace key to install into (default: root)")
+	cmd.Flags().StringVar(&workspace, "workspace", "", "Workspace key to install into (default: current workspace, else root)")
 	cmd.Flags().BoolVar(&dev, "dev", false, "Install as a development dependency")
 	cmd.Flags().BoolVar(&peer, "peer", false, "Install as a peer dependency")
 	cmd.Flags().BoolVar(&optional, "optional", false, "Install as an optional dependency")
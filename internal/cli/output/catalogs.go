@@ -0,0 +1,514 @@
+package output
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"ordo/internal/app"
+)
+
+const defaultCatalogName = "default"
+
+type jsonCatalog struct {
+	Name    string             `json:"name"`
+	Default bool               `json:"default"`
+	Entries []jsonCatalogEntry `json:"entries"`
+}
+
+type jsonCatalogEntry struct {
+	Package    string   `json:"package"`
+	Version    string   `json:"version"`
+	Workspaces []string `json:"workspaces"`
+}
+
+// Catalogs prints catalog listings as a table, or keeps them as the JSON result.
+func (p Printer) Catalogs(cmd Command, listings []app.CatalogListing) error {
+	if outputFormat == formatJSON {
+		app.ReportFrom(cmd.Context()).SetResult(jsonCatalogs(listings))
+		return nil
+	}
+
+	w := cmd.OutOrStdout()
+	rows := 0
+	table := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(table, "CATALOG\tPACKAGE\tVERSION\tWORKSPACES")
+	for _, listing := range listings {
+		for _, entry := range listing.Entries {
+			workspaces := "-"
+			if len(entry.Workspaces) > 0 {
+				workspaces = strings.Join(entry.Workspaces, ", ")
+			}
+			fmt.Fprintf(table, "%s\t%s\t%s\t%s\n", catalogDisplayName(listing.Name), entry.Package, entry.Version, workspaces)
+			rows++
+		}
+	}
+	if rows == 0 {
+		return writeLevelLine(w, levelInfo, "no catalog entries found")
+	}
+	return table.Flush()
+}
+
+// CatalogPrune reports the catalog entries a prune removed, or would remove in
+// a dry run.
+func (p Printer) CatalogPrune(cmd Command, pruned []app.CatalogListing) error {
+	if outputFormat == formatJSON {
+		app.ReportFrom(cmd.Context()).SetResult(jsonCatalogs(pruned))
+		return nil
+	}
+
+	w := cmd.OutOrStdout()
+	if len(pruned) == 0 {
+		return writeLevelLine(w, levelInfo, "no unused catalog entries")
+	}
+	level, verb := levelOK, "pruned"
+	if app.IsDryRun(cmd.Context()) {
+		level, verb = levelInfo, "would prune"
+	}
+	for _, listing := range pruned {
+		for _, entry := range listing.Entries {
+			if err := writeLevelLine(w, level, "%s %s@%s from %s catalog", verb, entry.Package, entry.Version, catalogDisplayName(listing.Name)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func jsonCatalogs(listings []app.CatalogListing) []jsonCatalog {
+	catalogs := make([]jsonCatalog, 0, len(listings))
+	for _, listing := range listings {
+		catalog := jsonCatalog{
+			Name:    catalogDisplayName(listing.Name),
+			Default: listing.Name == "",
+			Entries: make([]jsonCatalogEntry, 0, len(listing.Entries)),
+		}
+		for _, entry := range listing.Entries {
+			workspaces := entry.Workspaces
+			if workspaces == nil {
+				workspaces = []string{}
+			}
+			catalog.Entries = append(catalog.Entries, jsonCatalogEntry{
+				Package:    entry.Package,
+				Version:    entry.Version,
+				Workspaces: workspaces,
+			})
+		}
+		catalogs = append(catalogs, catalog)
+	}
+	return catalogs
+}
+
+func catalogDisplayName(name string) string {
+	if name == "" {
+		return defaultCatalogName
+	}
+	return name
+}
+
+type jsonCatalogOutdated struct {
+	Catalog string `json:"catalog"`
+	Package string `json:"package"`
+	Range   string `json:"range"`
+	Wanted  string `json:"wanted,omitempty"`
+	Latest  string `json:"latest,omitempty"`
+	Skipped string `json:"skipped,omitempty"`
+}
+
+type jsonCatalogUpgrade struct {
+	Catalog string `json:"catalog"`
+	Package string `json:"package"`
+	From    string `json:"from"`
+	To      string `json:"to,omitempty"`
+	Skipped string `json:"skipped,omitempty"`
+}
+
+// CatalogOutdated prints outdated catalog entries as a table, or keeps them as
+// the JSON result.
+func (p Printer) CatalogOutdated(cmd Command, entries []app.CatalogOutdatedEntry) error {
+	if outputFormat == formatJSON {
+		items := make([]jsonCatalogOutdated, 0, len(entries))
+		for _, entry := range entries {
+			items = append(items, jsonCatalogOutdated{
+				Catalog: catalogDisplayName(entry.Catalog),
+				Package: entry.Package,
+				Range:   entry.Range,
+				Wanted:  entry.Wanted,
+				Latest:  entry.Latest,
+				Skipped: entry.Skipped,
+			})
+		}
+		app.ReportFrom(cmd.Context()).SetResult(items)
+		return nil
+	}
+
+	stale := make([]app.CatalogOutdatedEntry, 0, len(entries))
+	for _, entry := range entries {
+		if entry.Skipped == "" {
+			stale = append(stale, entry)
+			continue
+		}
+		if err := writeLevelLine(cmd.ErrOrStderr(), levelWarn, "skipped %s in %s catalog: %s (%s)", entry.Package, catalogDisplayName(entry.Catalog), entry.Skipped, entry.Range); err != nil {
+			return err
+		}
+	}
+
+	w := cmd.OutOrStdout()
+	if len(stale) == 0 {
+		return writeLevelLine(w, levelInfo, "all catalog entries are up to date")
+	}
+	table := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(table, "CATALOG\tPACKAGE\tRANGE\tWANTED\tLATEST")
+	for _, entry := range stale {
+		wanted := entry.Wanted
+		if wanted == "" {
+			wanted = "-"
+		}
+		fmt.Fprintf(table, "%s\t%s\t%s\t%s\t%s\n", catalogDisplayName(entry.Catalog), entry.Package, entry.Range, wanted, entry.Latest)
+	}
+	return table.Flush()
+}
+
+// CatalogUpgrade reports rewritten and skipped catalog entries.
+func (p Printer) CatalogUpgrade(cmd Command, upgrades []app.CatalogUpgrade) error {
+	if outputFormat == formatJSON {
+		items := make([]jsonCatalogUpgrade, 0, len(upgrades))
+		for _, upgrade := range upgrades {
+			items = append(items, jsonCatalogUpgrade{
+				Catalog: catalogDisplayName(upgrade.Catalog),
+				Package: upgrade.Package,
+				From:    upgrade.From,
+				To:      upgrade.To,
+				Skipped: upgrade.Skipped,
+			})
+		}
+		app.ReportFrom(cmd.Context()).SetResult(items)
+		return nil
+	}
+
+	w := cmd.OutOrStdout()
+	if len(upgrades) == 0 {
+		return writeLevelLine(w, levelInfo, "all catalog entries are up to date")
+	}
+	level, verb := levelOK, "upgraded"
+	if app.IsDryRun(cmd.Context()) {
+		level, verb = levelInfo, "would upgrade"
+	}
+	for _, upgrade := range upgrades {
+		var err error
+		if upgrade.Skipped != "" {
+			err = writeLevelLine(cmd.ErrOrStderr(), levelWarn, "skipped %s in %s catalog: %s (%s)", upgrade.Package, catalogDisplayName(upgrade.Catalog), upgrade.Skipped, upgrade.From)
+		} else {
+			err = writeLevelLine(w, level, "%s %s in %s catalog: %s -> %s", verb, upgrade.Package, catalogDisplayName(upgrade.Catalog), upgrade.From, upgrade.To)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type jsonCatalogConsolidation struct {
+	Package    string            `json:"package"`
+	Range      string            `json:"range,omitempty"`
+	Workspaces map[string]string `json:"workspaces"`
+	Skipped    string            `json:"skipped,omitempty"`
+}
+
+// CatalogConsolidate reports packages moved into the default catalog.
+func (p Printer) CatalogConsolidate(cmd Command, results []app.CatalogConsolidation) error {
+	if outputFormat == formatJSON {
+		items := make([]jsonCatalogConsolidation, 0, len(results))
+		for _, result := range results {
+			items = append(items, jsonCatalogConsolidation{
+				Package:    result.Package,
+				Range:      result.Range,
+				Workspaces: result.Workspaces,
+				Skipped:    result.Skipped,
+			})
+		}
+		app.ReportFrom(cmd.Context()).SetResult(items)
+		return nil
+	}
+
+	w := cmd.OutOrStdout()
+	if len(results) == 0 {
+		return writeLevelLine(w, levelInfo, "no drifting dependency versions found")
+	}
+	level, verb := levelOK, "consolidated"
+	if app.IsDryRun(cmd.Context()) {
+		level, verb = levelInfo, "would consolidate"
+	}
+	for _, result := range results {
+		keys := make([]string, 0, len(result.Workspaces))
+		for key, version := range result.Workspaces {
+			keys = append(keys, key+"@"+version)
+		}
+		sort.Strings(keys)
+
+		var err error
+		if result.Skipped != "" {
+			err = writeLevelLine(cmd.ErrOrStderr(), levelWarn, "skipped %s: %s (%s)", result.Package, result.Skipped, strings.Join(keys, ", "))
+		} else {
+			err = writeLevelLine(w, level, "%s %s to %s (%s)", verb, result.Package, result.Range, strings.Join(keys, ", "))
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type jsonCatalogInlining struct {
+	Catalog    string   `json:"catalog"`
+	Package    string   `json:"package"`
+	Version    string   `json:"version"`
+	Workspaces []string `json:"workspaces"`
+	Removed    bool     `json:"removed"`
+}
+
+// CatalogInline reports catalog entries written back into manifests.
+func (p Printer) CatalogInline(cmd Command, results []app.CatalogInlining) error {
+	if outputFormat == formatJSON {
+		items := make([]jsonCatalogInlining, 0, len(results))
+		for _, result := range results {
+			items = append(items, jsonCatalogInlining{
+				Catalog:    catalogDisplayName(result.Catalog),
+				Package:    result.Package,
+				Version:    result.Version,
+				Workspaces: result.Workspaces,
+				Removed:    result.Removed,
+			})
+		}
+		app.ReportFrom(cmd.Context()).SetResult(items)
+		return nil
+	}
+
+	w := cmd.OutOrStdout()
+	if len(results) == 0 {
+		return writeLevelLine(w, levelInfo, "no catalog references found")
+	}
+	level, inlined, removed := levelOK, "inlined", "removed"
+	if app.IsDryRun(cmd.Context()) {
+		level, inlined, removed = levelInfo, "would inline", "would remove"
+	}
+	for _, result := range results {
+		name := catalogDisplayName(result.Catalog)
+		if err := writeLevelLine(w, level, "%s %s@%s from %s catalog into %s", inlined, result.Package, result.Version, name, strings.Join(result.Workspaces, ", ")); err != nil {
+			return err
+		}
+		if !result.Removed {
+			continue
+		}
+		if err := writeLevelLine(w, level, "%s %s from %s catalog", removed, result.Package, name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type jsonCatalogMigration struct {
+	From     string        `json:"from"`
+	To       string        `json:"to"`
+	Catalogs []jsonCatalog `json:"catalogs"`
+}
+
+// CatalogMigrate reports catalogs moved between package managers.
+func (p Printer) CatalogMigrate(cmd Command, migration app.CatalogMigration) error {
+	if outputFormat == formatJSON {
+		app.ReportFrom(cmd.Context()).SetResult(jsonCatalogMigration{
+			From:     string(migration.From),
+			To:       string(migration.To),
+			Catalogs: jsonCatalogs(migration.Catalogs),
+		})
+		return nil
+	}
+
+	w := cmd.OutOrStdout()
+	if len(migration.Catalogs) == 0 {
+		return writeLevelLine(w, levelInfo, "no %s catalog entries to migrate", migration.From)
+	}
+	level, verb := levelOK, "migrated"
+	if app.IsDryRun(cmd.Context()) {
+		level, verb = levelInfo, "would migrate"
+	}
+	for _, listing := range migration.Catalogs {
+		if err := writeLevelLine(w, level, "%s %s catalog (%s) from %s to %s", verb, catalogDisplayName(listing.Name), entryCount(len(listing.Entries)), migration.From, migration.To); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func entryCount(n int) string {
+	if n == 1 {
+		return "1 entry"
+	}
+	return fmt.Sprintf("%d entries", n)
+}
+
+type jsonCatalogSync struct {
+	Catalogs  []string               `json:"catalogs"`
+	Ambiguous []jsonCatalogAmbiguity `json:"ambiguous"`
+}
+
+type jsonCatalogAmbiguity struct {
+	Package  string   `json:"package"`
+	Catalogs []string `json:"catalogs"`
+}
+
+// CatalogSync warns about packages sync left alone because several catalogs
+// define them.
+func (p Printer) CatalogSync(cmd Command, result app.CatalogSyncResult) error {
+	if outputFormat == formatJSON {
+		out := jsonCatalogSync{
+			Catalogs:  make([]string, 0, len(result.Catalogs)),
+			Ambiguous: make([]jsonCatalogAmbiguity, 0, len(result.Ambiguous)),
+		}
+		for _, name := range result.Catalogs {
+			out.Catalogs = append(out.Catalogs, catalogDisplayName(name))
+		}
+		for _, item := range result.Ambiguous {
+			out.Ambiguous = append(out.Ambiguous, jsonCatalogAmbiguity{Package: item.Package, Catalogs: catalogDisplayNames(item.Catalogs)})
+		}
+		app.ReportFrom(cmd.Context()).SetResult(out)
+		return nil
+	}
+
+	for _, item := range result.Ambiguous {
+		err := writeLevelLine(cmd.ErrOrStderr(), levelWarn, "skipped %s: defined in %s catalogs; reference one explicitly", item.Package, strings.Join(catalogDisplayNames(item.Catalogs), ", "))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func catalogDisplayNames(names []string) []string {
+	out := make([]string, 0, len(names))
+	for _, name := range names {
+		out = append(out, catalogDisplayName(name))
+	}
+	return out
+}
+
+type jsonCatalogRename struct {
+	From       string   `json:"from"`
+	To         string   `json:"to"`
+	Workspaces []string `json:"workspaces"`
+}
+
+// CatalogRename reports a renamed catalog and the workspaces it rewrote.
+func (p Printer) CatalogRename(cmd Command, rename app.CatalogRename) error {
+	if outputFormat == formatJSON {
+		app.ReportFrom(cmd.Context()).SetResult(jsonCatalogRename{From: rename.From, To: rename.To, Workspaces: rename.Workspaces})
+		return nil
+	}
+
+	level, verb := levelOK, "renamed"
+	if app.IsDryRun(cmd.Context()) {
+		level, verb = levelInfo, "would rename"
+	}
+	w := cmd.OutOrStdout()
+	if len(rename.Workspaces) == 0 {
+		return writeLevelLine(w, level, "%s %s catalog to %s", verb, rename.From, rename.To)
+	}
+	return writeLevelLine(w, level, "%s %s catalog to %s (references in %s)", verb, rename.From, rename.To, strings.Join(rename.Workspaces, ", "))
+}
+
+type jsonCatalogCopy struct {
+	From    string `json:"from"`
+	To      string `json:"to"`
+	Entries int    `json:"entries"`
+}
+
+// CatalogCopy reports a catalog duplicated under a new name.
+func (p Printer) CatalogCopy(cmd Command, copied app.CatalogCopy) error {
+	if outputFormat == formatJSON {
+		app.ReportFrom(cmd.Context()).SetResult(jsonCatalogCopy{From: copied.From, To: copied.To, Entries: copied.Entries})
+		return nil
+	}
+
+	level, verb := levelOK, "copied"
+	if app.IsDryRun(cmd.Context()) {
+		level, verb = levelInfo, "would copy"
+	}
+	return writeLevelLine(cmd.OutOrStdout(), level, "%s %s catalog to %s (%s)", verb, copied.From, copied.To, entryCount(copied.Entries))
+}
+
+type jsonCatalogDeletion struct {
+	Name       string   `json:"name"`
+	Entries    int      `json:"entries"`
+	Workspaces []string `json:"workspaces"`
+}
+
+// CatalogDelete reports a deleted catalog and the workspaces whose references
+// were inlined first.
+func (p Printer) CatalogDelete(cmd Command, deletion app.CatalogDeletion) error {
+	if outputFormat == formatJSON {
+		app.ReportFrom(cmd.Context()).SetResult(jsonCatalogDeletion{Name: deletion.Name, Entries: deletion.Entries, Workspaces: deletion.Workspaces})
+		return nil
+	}
+
+	level, inlined, deleted := levelOK, "inlined", "deleted"
+	if app.IsDryRun(cmd.Context()) {
+		level, inlined, deleted = levelInfo, "would inline", "would delete"
+	}
+	w := cmd.OutOrStdout()
+	if len(deletion.Workspaces) > 0 {
+		if err := writeLevelLine(w, level, "%s %s catalog references in %s", inlined, deletion.Name, strings.Join(deletion.Workspaces, ", ")); err != nil {
+			return err
+		}
+	}
+	return writeLevelLine(w, level, "%s %s catalog (%s)", deleted, deletion.Name, entryCount(deletion.Entries))
+}
+
+type jsonCatalogViolation struct {
+	Kind      string   `json:"kind"`
+	Workspace string   `json:"workspace"`
+	Bucket    string   `json:"bucket"`
+	Package   string   `json:"package"`
+	Spec      string   `json:"spec"`
+	Catalogs  []string `json:"catalogs"`
+}
+
+// CatalogCheck reports each catalog violation with its workspace and
+// dependency bucket.
+func (p Printer) CatalogCheck(cmd Command, violations []app.CatalogViolation) error {
+	if outputFormat == formatJSON {
+		items := make([]jsonCatalogViolation, 0, len(violations))
+		for _, v := range violations {
+			items = append(items, jsonCatalogViolation{
+				Kind:      string(v.Kind),
+				Workspace: v.Workspace,
+				Bucket:    string(v.Bucket),
+				Package:   v.Package,
+				Spec:      v.Spec,
+				Catalogs:  catalogDisplayNames(v.Catalogs),
+			})
+		}
+		app.ReportFrom(cmd.Context()).SetResult(items)
+		return nil
+	}
+
+	w := cmd.OutOrStdout()
+	if len(violations) == 0 {
+		return writeLevelLine(w, levelOK, "catalog references are consistent")
+	}
+	for _, v := range violations {
+		var detail string
+		switch v.Kind {
+		case app.ViolationUnknownCatalog:
+			detail = fmt.Sprintf("%s references unknown catalog %s", v.Package, catalogDisplayName(v.Catalogs[0]))
+		case app.ViolationMissingEntry:
+			detail = fmt.Sprintf("%s is missing from %s catalog", v.Package, catalogDisplayName(v.Catalogs[0]))
+		default:
+			detail = fmt.Sprintf("%s declares %s instead of a reference to %s catalog", v.Package, v.Spec, strings.Join(catalogDisplayNames(v.Catalogs), " or "))
+		}
+		if err := writeLevelLine(w, levelError, "%s %s: %s", v.Workspace, v.Bucket, detail); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package output
+
+import "testing"
+
+func TestUnifiedDiff(t *testing.T) {
+	before := "{\n  \"name\": \"web\",\n  \"dependencies\": {\n    \"react\": \"^18.0.0\"\n  }\n}\n"
+	after := "{\n  \"name\": \"web\",\n  \"dependencies\": {\n    \"react\": \"catalog:\"\n  }\n}\n"
+
+	got := UnifiedDiff("apps/web/package.json", []byte(before), []byte(after))
+	want := `--- a/apps/web/package.json
++++ b/apps/web/package.json
+@@ -1,6 +1,6 @@
+ {
+   "name": "web",
+   "dependencies": {
+-    "react": "^18.0.0"
++    "react": "catalog:"
+   }
+ }
+`
+	if got != want {
+		t.Fatalf("diff =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestUnifiedDiffNewFile(t *testing.T) {
+	got := UnifiedDiff("pnpm-workspace.yaml", nil, []byte("catalog:\n  zod: ^3.0.0\n"))
+	want := `--- /dev/null
++++ b/pnpm-workspace.yaml
+@@ -0,0 +1,2 @@
++catalog:
++  zod: ^3.0.0
+`
+	if got != want {
+		t.Fatalf("diff =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestUnifiedDiffSplitsDistantChanges(t *testing.T) {
+	before := "a\nb\nc\nd\ne\nf\ng\nh\ni\nj\n"
+	after := "A\nb\nc\nd\ne\nf\ng\nh\ni\nJ\n"
+
+	got := UnifiedDiff("x", []byte(before), []byte(after))
+	want := `--- a/x
++++ b/x
+@@ -1,4 +1,4 @@
+-a
++A
+ b
+ c
+ d
+@@ -7,4 +7,4 @@
+ g
+ h
+ i
+-j
++J
+`
+	if got != want {
+		t.Fatalf("diff =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestUnifiedDiffIdentical(t *testing.T) {
+	if got := UnifiedDiff("x", []byte("a\n"), []byte("a\n")); got != "" {
+		t.Fatalf("diff = %q, want empty", got)
+	}
+}
@@ -0,0 +1,192 @@
+package output
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"ordo/internal/app"
+	"ordo/internal/domain"
+)
+
+const (
+	formatText = "text"
+	formatJSON = "json"
+)
+
+var outputFormat = formatText
+
+// Command is the part of a cobra command the printer needs.
+type Command interface {
+	Context() context.Context
+	CommandPath() string
+	OutOrStdout() io.Writer
+	ErrOrStderr() io.Writer
+}
+
+type jsonResult struct {
+	OK             bool          `json:"ok"`
+	Command        string        `json:"command"`
+	Manager        string        `json:"manager,omitempty"`
+	ManagerVersion string        `json:"managerVersion,omitempty"`
+	Commands       []jsonCommand `json:"commands"`
+	Packages       []string      `json:"packages"`
+	DryRun         bool          `json:"dryRun,omitempty"`
+	Files          []jsonFile    `json:"files,omitempty"`
+	Result         any           `json:"result,omitempty"`
+	Error          *jsonError    `json:"error,omitempty"`
+}
+
+type jsonFile struct {
+	Path string `json:"path"`
+	Diff string `json:"diff"`
+}
+
+type jsonCommand struct {
+	Dir      string   `json:"dir"`
+	Argv     []string `json:"argv"`
+	ExitCode int      `json:"exitCode"`
+}
+
+type jsonError struct {
+	Code     string `json:"code"`
+	Message  string `json:"message"`
+	ExitCode int    `json:"exitCode"`
+}
+
+type jsonLine struct {
+	Level   string `json:"level"`
+	Message string `json:"message"`
+}
+
+func ParseOutputFormat(raw string) (string, error) {
+	format := strings.ToLower(strings.TrimSpace(raw))
+	switch format {
+	case formatText, formatJSON:
+		return format, nil
+	default:
+		return "", fmt.Errorf("invalid value for --output: %q (want text or json)", raw)
+	}
+}
+
+func SetOutputFormat(format string) {
+	outputFormat = format
+}
+
+// CommandOutput returns where child processes should write their stdout. In
+// JSON mode it is stderr, so stdout carries nothing but the result document.
+func CommandOutput() *os.File {
+	if outputFormat == formatJSON {
+		return os.Stderr
+	}
+	return os.Stdout
+}
+
+// ErrorCode returns a stable, machine-readable code for err.
+func ErrorCode(err error) string {
+	var missingGlobal app.GlobalPackageMissingError
+	var exitStatus interface{ ExitCode() int }
+	switch {
+	case errors.Is(err, domain.ErrInvalidTarget):
+		return "invalid_target"
+	case errors.Is(err, domain.ErrDependencyCycle):
+		return "dependency_cycle"
+	case errors.Is(err, app.ErrWorkspaceNotFound):
+		return "workspace_not_found"
+	case errors.Is(err, app.ErrScriptNotFound):
+		return "script_not_found"
+	case errors.Is(err, app.ErrPackageNotFound):
+		return "package_not_found"
+	case errors.Is(err, app.ErrConfigAlreadyExists):
+		return "config_already_exists"
+	case errors.Is(err, app.ErrConfigNotFound):
+		return "config_not_found"
+	case errors.Is(err, app.ErrPresetNotFound):
+		return "preset_not_found"
+	case errors.Is(err, app.ErrPresetAlreadyExists):
+		return "preset_already_exists"
+	case errors.Is(err, app.ErrInvalidPresetName):
+		return "invalid_preset_name"
+	case errors.Is(err, app.ErrPresetCycle):
+		return "preset_cycle"
+	case errors.Is(err, app.ErrPresetInUse):
+		return "preset_in_use"
+	case errors.Is(err, app.ErrInvalidPresetEntry):
+		return "invalid_preset_entry"
+	case errors.Is(err, app.ErrPresetBucketNotFound):
+		return "preset_bucket_not_found"
+	case errors.Is(err, app.ErrPresetPackageNotFound):
+		return "preset_package_not_found"
+	case errors.Is(err, app.ErrCatalogUnsupported):
+		return "catalog_unsupported"
+	case errors.Is(err, app.ErrCatalogConflict):
+		return "catalog_conflict"
+	case errors.Is(err, app.ErrCatalogNotFound):
+		return "catalog_not_found"
+	case errors.Is(err, app.ErrCatalogCheckFailed):
+		return "catalog_check_failed"
+	case errors.Is(err, app.ErrCatalogInUse):
+		return "catalog_in_use"
+	case errors.Is(err, app.ErrInvalidCatalogName):
+		return "invalid_catalog_name"
+	case errors.Is(err, app.ErrAmbiguousManager):
+		return "ambiguous_manager"
+	case errors.As(err, &missingGlobal):
+		return "global_package_not_found"
+	case errors.As(err, &exitStatus):
+		return "command_failed"
+	default:
+		return "error"
+	}
+}
+
+func writeJSONResult(w io.Writer, cmd Command, err error) error {
+	report := app.ReportFrom(cmd.Context())
+	result := jsonResult{
+		OK:             err == nil,
+		Command:        cmd.CommandPath(),
+		Manager:        string(report.Manager()),
+		ManagerVersion: report.ManagerVersion(),
+		Commands:       []jsonCommand{},
+		Packages:       report.Packages(),
+		DryRun:         app.IsDryRun(cmd.Context()),
+		Result:         report.Result(),
+	}
+	for _, record := range report.Commands() {
+		result.Commands = append(result.Commands, jsonCommand{
+			Dir:      record.Dir,
+			Argv:     record.Argv,
+			ExitCode: record.ExitCode,
+		})
+	}
+	for _, file := range report.Files() {
+		result.Files = append(result.Files, jsonFile{
+			Path: file.Path,
+			Diff: UnifiedDiff(file.Path, file.Before, file.After),
+		})
+	}
+	if result.Packages == nil {
+		result.Packages = []string{}
+	}
+	if err != nil {
+		result.Error = &jsonError{
+			Code:     ErrorCode(err),
+			Message:  err.Error(),
+			ExitCode: ExitCode(err),
+		}
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetEscapeHTML(false)
+	return encoder.Encode(result)
+}
+
+func writeJSONLine(w io.Writer, level string, msg string) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetEscapeHTML(false)
+	return encoder.Encode(jsonLine{Level: strings.ToLower(level), Message: msg})
+}
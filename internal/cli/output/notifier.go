@@ -0,0 +1,28 @@
+package output
+
+import (
+	"io"
+	"sync"
+)
+
+// Notifier writes warnings as WARN lines, printing each distinct message once.
+type Notifier struct {
+	w    io.Writer
+	mu   sync.Mutex
+	seen map[string]struct{}
+}
+
+func NewNotifier(w io.Writer) *Notifier {
+	return &Notifier{w: w, seen: map[string]struct{}{}}
+}
+
+func (n *Notifier) Warn(message string) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if _, ok := n.seen[message]; ok {
+		return
+	}
+	n.seen[message] = struct{}{}
+	_ = writeLevelLine(n.w, levelWarn, "%s", message)
+}
@@ -0,0 +1,51 @@
+package output
+
+import (
+	"hash/fnv"
+	"io"
+	"strings"
+
+	"ordo/internal/ports"
+)
+
+var workspaceColors = []string{
+	"\x1b[36m",
+	"\x1b[35m",
+	"\x1b[32m",
+	"\x1b[33m",
+	"\x1b[34m",
+	"\x1b[96m",
+	"\x1b[95m",
+	"\x1b[92m",
+}
+
+// WorkspacePrefixer returns a function formatting the line prefixes for a
+// workspace's stdout and stderr. Keys are padded to width and, on each stream
+// with color enabled, each workspace keeps a stable color.
+func WorkspacePrefixer(stdout io.Writer, stderr io.Writer) func(string, int) ports.StreamPrefix {
+	stdoutColor := shouldColorize(stdout, outputColorMode)
+	stderrColor := shouldColorize(stderr, outputColorMode)
+	return func(workspace string, width int) ports.StreamPrefix {
+		label := workspace
+		if pad := width - len(workspace); pad > 0 {
+			label += strings.Repeat(" ", pad)
+		}
+		return ports.StreamPrefix{
+			Stdout: workspacePrefix(workspace, label, stdoutColor),
+			Stderr: workspacePrefix(workspace, label, stderrColor),
+		}
+	}
+}
+
+func workspacePrefix(workspace string, label string, colorEnabled bool) string {
+	if colorEnabled {
+		label = workspaceColor(workspace) + label + ansiReset
+	}
+	return label + " | "
+}
+
+func workspaceColor(workspace string) string {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(workspace))
+	return workspaceColors[h.Sum32()%uint32(len(workspaceColors))]
+}
@@ -0,0 +1,32 @@
+package output
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestWorkspacePrefixerColorsEachStreamSeparately(t *testing.T) {
+	withOutputColorMode(t, colorModeAuto)
+	if _, disabled := os.LookupEnv(envColorDisable); disabled {
+		t.Skipf("%s is set", envColorDisable)
+	}
+	// /dev/null is a character device, so it passes for a terminal.
+	terminal, err := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	if err != nil {
+		t.Skipf("open %s: %v", os.DevNull, err)
+	}
+	defer terminal.Close()
+	if !isTTY(terminal) {
+		t.Skipf("%s is not a character device", os.DevNull)
+	}
+
+	prefix := WorkspacePrefixer(&bytes.Buffer{}, terminal)("ui", 4)
+	if prefix.Stdout != "ui   | " {
+		t.Fatalf("stdout prefix = %q, want it uncolored", prefix.Stdout)
+	}
+	if !strings.HasPrefix(prefix.Stderr, "\x1b[") || !strings.HasSuffix(prefix.Stderr, "ui  "+ansiReset+" | ") {
+		t.Fatalf("stderr prefix = %q, want it colored", prefix.Stderr)
+	}
+}
@@ -0,0 +1,106 @@
+package output
+
+import (
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"ordo/internal/app"
+)
+
+type jsonPreset struct {
+	Name    string              `json:"name"`
+	Extends []string            `json:"extends"`
+	Buckets map[string][]string `json:"buckets"`
+}
+
+// Presets prints presets as a table, or keeps them as the JSON result.
+func (p Printer) Presets(cmd Command, listings []app.PresetListing) error {
+	if outputFormat == formatJSON {
+		presets := make([]jsonPreset, 0, len(listings))
+		for _, listing := range listings {
+			presets = append(presets, jsonPresetFrom(listing))
+		}
+		app.ReportFrom(cmd.Context()).SetResult(presets)
+		return nil
+	}
+
+	w := cmd.OutOrStdout()
+	if len(listings) == 0 {
+		return writeLevelLine(w, levelInfo, "no presets found")
+	}
+	table := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(table, "PRESET\tBUCKET\tPACKAGES")
+	for _, listing := range listings {
+		if len(listing.Extends) > 0 {
+			fmt.Fprintf(table, "%s\textends\t%s\n", listing.Name, strings.Join(listing.Extends, ", "))
+		}
+		if len(listing.Buckets) == 0 && len(listing.Extends) == 0 {
+			fmt.Fprintf(table, "%s\t-\t-\n", listing.Name)
+			continue
+		}
+		for _, bucket := range listing.Buckets {
+			fmt.Fprintf(table, "%s\t%s\t%s\n", listing.Name, bucket.Bucket, strings.Join(bucket.Packages, ", "))
+		}
+	}
+	return table.Flush()
+}
+
+// PresetSave reports a preset captured from a workspace.
+func (p Printer) PresetSave(cmd Command, listing app.PresetListing, workspace string) error {
+	if outputFormat == formatJSON {
+		app.ReportFrom(cmd.Context()).SetResult(jsonPresetFrom(listing))
+		return nil
+	}
+
+	level, verb := levelOK, "saved"
+	if app.IsDryRun(cmd.Context()) {
+		level, verb = levelInfo, "would save"
+	}
+	w := cmd.OutOrStdout()
+	for _, bucket := range listing.Buckets {
+		if err := writeLevelLine(w, level, "%s %s %s from %s: %s", verb, listing.Name, bucket.Bucket, workspace, strings.Join(bucket.Packages, ", ")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func jsonPresetFrom(listing app.PresetListing) jsonPreset {
+	preset := jsonPreset{Name: listing.Name, Extends: listing.Extends, Buckets: map[string][]string{}}
+	if preset.Extends == nil {
+		preset.Extends = []string{}
+	}
+	for _, bucket := range listing.Buckets {
+		preset.Buckets[string(bucket.Bucket)] = bucket.Packages
+	}
+	return preset
+}
+
+type jsonPresetApplication struct {
+	Preset    string   `json:"preset"`
+	Workspace string   `json:"workspace"`
+	Applied   []string `json:"applied"`
+}
+
+// PresetApply reports the preset buckets installed by preset apply.
+func (p Printer) PresetApply(cmd Command, result app.PresetApplication) error {
+	applied := make([]string, 0, len(result.Applied))
+	for _, bucket := range result.Applied {
+		applied = append(applied, string(bucket))
+	}
+	if outputFormat == formatJSON {
+		app.ReportFrom(cmd.Context()).SetResult(jsonPresetApplication{
+			Preset:    result.Preset,
+			Workspace: result.Workspace,
+			Applied:   applied,
+		})
+		return nil
+	}
+
+	level, verb := levelOK, "applied"
+	if app.IsDryRun(cmd.Context()) {
+		level, verb = levelInfo, "would apply"
+	}
+	return writeLevelLine(cmd.OutOrStdout(), level, "%s %s to %s: %s", verb, result.Preset, result.Workspace, strings.Join(applied, ", "))
+}
@@ -49,12 +49,22 @@ func NewPrinter() Printer {
 	return Printer{}
 }
 
-func (p Printer) Handle(errWriter io.Writer, err error) error {
+// Handle reports the outcome of cmd. Text output only prints errors; JSON output
+// always writes a result document to stdout.
+func (p Printer) Handle(cmd Command, err error) error {
+	if outputFormat == formatJSON {
+		_ = writeJSONResult(cmd.OutOrStdout(), cmd, err)
+	} else {
+		if app.IsDryRun(cmd.Context()) {
+			_ = writeDryRun(cmd.OutOrStdout(), app.ReportFrom(cmd.Context()))
+		}
+		if err != nil {
+			_ = PrintRootError(cmd.ErrOrStderr(), err)
+		}
+	}
 	if err == nil {
 		return nil
 	}
-
-	_ = PrintRootError(errWriter, err)
 	return &ExitError{Code: ExitCode(err)}
 }
 
@@ -144,6 +154,9 @@ func formatLevel(level string, colorEnabled bool) string {
 
 func writeLevelLine(w io.Writer, level string, format string, args ...any) error {
 	msg := fmt.Sprintf(format, args...)
+	if outputFormat == formatJSON {
+		return writeJSONLine(w, level, msg)
+	}
 	if !outputShowLevel {
 		_, err := fmt.Fprintf(w, "%s\n", msg)
 		return err
@@ -153,6 +166,29 @@ func writeLevelLine(w io.Writer, level string, format string, args ...any) error
 	return err
 }
 
+// writeDryRun lists the commands and file changes a dry run skipped.
+func writeDryRun(w io.Writer, report *app.Report) error {
+	commands := report.Commands()
+	files := report.Files()
+	if len(commands) == 0 && len(files) == 0 {
+		return writeLevelLine(w, levelInfo, "dry run: nothing to do")
+	}
+	for _, command := range commands {
+		if err := writeLevelLine(w, levelInfo, "would run in %s: %s", command.Dir, strings.Join(command.Argv, " ")); err != nil {
+			return err
+		}
+	}
+	for _, file := range files {
+		if err := writeLevelLine(w, levelInfo, "would write %s", file.Path); err != nil {
+			return err
+		}
+		if _, err := io.WriteString(w, UnifiedDiff(file.Path, file.Before, file.After)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // PrintRootError writes a top-level CLI error using the shared output format.
 func PrintRootError(w io.Writer, err error) error {
 	return writeLevelLine(w, levelError, "%v", err)
@@ -164,8 +200,10 @@ func ExitCode(err error) int {
 		return 2
 	case errors.Is(err, app.ErrWorkspaceNotFound):
 		return 3
-	case errors.Is(err, app.ErrScriptNotFound), errors.Is(err, app.ErrPackageNotFound):
+	case errors.Is(err, app.ErrScriptNotFound), errors.Is(err, app.ErrPackageNotFound), errors.Is(err, app.ErrCatalogNotFound):
 		return 4
+	case errors.Is(err, app.ErrAmbiguousManager):
+		return 5
 	default:
 		return 1
 	}
@@ -2,8 +2,12 @@ package output
 
 import (
 	"bytes"
+	"context"
 	"errors"
+	"fmt"
+	"io"
 	"os"
+	"strings"
 	"testing"
 
 	"ordo/internal/app"
@@ -38,6 +42,7 @@ func TestExitCode(t *testing.T) {
 		{name: "workspace missing", err: app.ErrWorkspaceNotFound, want: 3},
 		{name: "script missing", err: app.ErrScriptNotFound, want: 4},
 		{name: "package missing", err: app.ErrPackageNotFound, want: 4},
+		{name: "ambiguous manager", err: app.AmbiguousManagerError{Lockfiles: []string{"pnpm-lock.yaml", "yarn.lock"}}, want: 5},
 		{name: "other", err: errors.New("boom"), want: 1},
 	}
 
@@ -188,3 +193,350 @@ func TestPrintRootErrorAutoHonorsNoColorsEnv(t *testing.T) {
 		t.Fatalf("stderr = %q, want %q", got, want)
 	}
 }
+
+type fakeCommand struct {
+	ctx    context.Context
+	stdout *bytes.Buffer
+	stderr *bytes.Buffer
+}
+
+func (c fakeCommand) Context() context.Context { return c.ctx }
+func (c fakeCommand) CommandPath() string      { return "ordo install" }
+func (c fakeCommand) OutOrStdout() io.Writer   { return c.stdout }
+func (c fakeCommand) ErrOrStderr() io.Writer   { return c.stderr }
+
+func withOutputFormat(t *testing.T, format string) {
+	t.Helper()
+	prev := outputFormat
+	SetOutputFormat(format)
+	t.Cleanup(func() {
+		SetOutputFormat(prev)
+	})
+}
+
+func TestHandleJSONReportsCommands(t *testing.T) {
+	withOutputFormat(t, formatJSON)
+
+	report := &app.Report{}
+	report.RecordCommand("packages/ui", []string{"pnpm", "add", "zod"}, 0)
+	cmd := fakeCommand{ctx: app.WithReport(context.Background(), report), stdout: &bytes.Buffer{}, stderr: &bytes.Buffer{}}
+
+	if err := NewPrinter().Handle(cmd, nil); err != nil {
+		t.Fatalf("Handle() error = %v", err)
+	}
+	want := `{"ok":true,"command":"ordo install","commands":[{"dir":"packages/ui","argv":["pnpm","add","zod"],"exitCode":0}],"packages":[]}` + "\n"
+	if got := cmd.stdout.String(); got != want {
+		t.Fatalf("stdout = %q, want %q", got, want)
+	}
+	if cmd.stderr.Len() != 0 {
+		t.Fatalf("stderr = %q, want empty", cmd.stderr.String())
+	}
+}
+
+func TestHandleJSONReportsErrorCode(t *testing.T) {
+	withOutputFormat(t, formatJSON)
+
+	cmd := fakeCommand{ctx: context.Background(), stdout: &bytes.Buffer{}, stderr: &bytes.Buffer{}}
+	err := NewPrinter().Handle(cmd, fmt.Errorf("%w: web", app.ErrWorkspaceNotFound))
+
+	var exitErr *ExitError
+	if !errors.As(err, &exitErr) || exitErr.Code != 3 {
+		t.Fatalf("Handle() error = %v, want exit code 3", err)
+	}
+	want := `{"ok":false,"command":"ordo install","commands":[],"packages":[],"error":{"code":"workspace_not_found","message":"workspace not found: web","exitCode":3}}` + "\n"
+	if got := cmd.stdout.String(); got != want {
+		t.Fatalf("stdout = %q, want %q", got, want)
+	}
+}
+
+func TestWriteLevelLineJSON(t *testing.T) {
+	withOutputFormat(t, formatJSON)
+
+	buf := &bytes.Buffer{}
+	if err := writeLevelLine(buf, levelWarn, "mixed %s", "lockfiles"); err != nil {
+		t.Fatalf("writeLevelLine() error = %v", err)
+	}
+	if got, want := buf.String(), `{"level":"warn","message":"mixed lockfiles"}`+"\n"; got != want {
+		t.Fatalf("output = %q, want %q", got, want)
+	}
+}
+
+func TestCatalogsTable(t *testing.T) {
+	withOutputFormat(t, formatText)
+
+	cmd := fakeCommand{ctx: context.Background(), stdout: &bytes.Buffer{}, stderr: &bytes.Buffer{}}
+	err := NewPrinter().Catalogs(cmd, []app.CatalogListing{
+		{Name: "", Entries: []app.CatalogListEntry{{Package: "react", Version: "^19.0.0", Workspaces: []string{".", "web"}}}},
+		{Name: "legacy", Entries: []app.CatalogListEntry{{Package: "clsx", Version: "^1.2.1"}}},
+	})
+	if err != nil {
+		t.Fatalf("Catalogs() error = %v", err)
+	}
+	want := "CATALOG  PACKAGE  VERSION  WORKSPACES\n" +
+		"default  react    ^19.0.0  ., web\n" +
+		"legacy   clsx     ^1.2.1   -\n"
+	if got := cmd.stdout.String(); got != want {
+		t.Fatalf("stdout =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestCatalogsJSONResult(t *testing.T) {
+	withOutputFormat(t, formatJSON)
+
+	report := &app.Report{}
+	cmd := fakeCommand{ctx: app.WithReport(context.Background(), report), stdout: &bytes.Buffer{}, stderr: &bytes.Buffer{}}
+	err := NewPrinter().Catalogs(cmd, []app.CatalogListing{
+		{Name: "", Entries: []app.CatalogListEntry{{Package: "react", Version: "^19.0.0"}}},
+	})
+	if err != nil {
+		t.Fatalf("Catalogs() error = %v", err)
+	}
+	if err := NewPrinter().Handle(cmd, nil); err != nil {
+		t.Fatalf("Handle() error = %v", err)
+	}
+	want := `{"ok":true,"command":"ordo install","commands":[],"packages":[],"result":[{"name":"default","default":true,"entries":[{"package":"react","version":"^19.0.0","workspaces":[]}]}]}` + "\n"
+	if got := cmd.stdout.String(); got != want {
+		t.Fatalf("stdout = %q, want %q", got, want)
+	}
+}
+
+func TestCatalogPruneDryRunListing(t *testing.T) {
+	withOutputFormat(t, formatText)
+	withOutputColorMode(t, colorModeNever)
+	withOutputShowLevel(t, true)
+
+	cmd := fakeCommand{ctx: app.WithDryRun(context.Background()), stdout: &bytes.Buffer{}, stderr: &bytes.Buffer{}}
+	err := NewPrinter().CatalogPrune(cmd, []app.CatalogListing{
+		{Name: "legacy", Entries: []app.CatalogListEntry{{Package: "clsx", Version: "^1.2.1"}}},
+	})
+	if err != nil {
+		t.Fatalf("CatalogPrune() error = %v", err)
+	}
+	if got, want := cmd.stdout.String(), "[INFO] would prune clsx@^1.2.1 from legacy catalog\n"; got != want {
+		t.Fatalf("stdout = %q, want %q", got, want)
+	}
+}
+
+func TestCatalogOutdatedTable(t *testing.T) {
+	withOutputFormat(t, formatText)
+
+	cmd := fakeCommand{ctx: context.Background(), stdout: &bytes.Buffer{}, stderr: &bytes.Buffer{}}
+	err := NewPrinter().CatalogOutdated(cmd, []app.CatalogOutdatedEntry{
+		{Package: "react", Range: "^18.2.0", Wanted: "18.3.1", Latest: "19.1.0"},
+		{Catalog: "legacy", Package: "clsx", Range: "^3.0.0", Latest: "2.1.1"},
+		{Package: "@acme/private", Range: "^1.0.0", Skipped: "package not found in the registry"},
+	})
+	if err != nil {
+		t.Fatalf("CatalogOutdated() error = %v", err)
+	}
+	want := "CATALOG  PACKAGE  RANGE    WANTED  LATEST\n" +
+		"default  react    ^18.2.0  18.3.1  19.1.0\n" +
+		"legacy   clsx     ^3.0.0   -       2.1.1\n"
+	if got := cmd.stdout.String(); got != want {
+		t.Fatalf("stdout =\n%s\nwant\n%s", got, want)
+	}
+	if got := cmd.stderr.String(); !strings.Contains(got, "skipped @acme/private in default catalog: package not found in the registry (^1.0.0)") {
+		t.Fatalf("stderr = %q, want a skipped warning", got)
+	}
+}
+
+func TestCatalogUpgradeLines(t *testing.T) {
+	withOutputFormat(t, formatText)
+	withOutputColorMode(t, colorModeNever)
+	withOutputShowLevel(t, true)
+
+	cmd := fakeCommand{ctx: context.Background(), stdout: &bytes.Buffer{}, stderr: &bytes.Buffer{}}
+	err := NewPrinter().CatalogUpgrade(cmd, []app.CatalogUpgrade{
+		{Package: "react", From: "^18.2.0", To: "^19.1.0"},
+		{Catalog: "legacy", Package: "clsx", From: ">=1 <2", Skipped: "range is not a single version with an operator"},
+	})
+	if err != nil {
+		t.Fatalf("CatalogUpgrade() error = %v", err)
+	}
+	if got, want := cmd.stdout.String(), "[OK] upgraded react in default catalog: ^18.2.0 -> ^19.1.0\n"; got != want {
+		t.Fatalf("stdout = %q, want %q", got, want)
+	}
+	if got, want := cmd.stderr.String(), "[WARN] skipped clsx in legacy catalog: range is not a single version with an operator (>=1 <2)\n"; got != want {
+		t.Fatalf("stderr = %q, want %q", got, want)
+	}
+}
+
+func TestCatalogConsolidateLines(t *testing.T) {
+	withOutputFormat(t, formatText)
+	withOutputColorMode(t, colorModeNever)
+	withOutputShowLevel(t, true)
+
+	cmd := fakeCommand{ctx: context.Background(), stdout: &bytes.Buffer{}, stderr: &bytes.Buffer{}}
+	err := NewPrinter().CatalogConsolidate(cmd, []app.CatalogConsolidation{
+		{Package: "zod", Range: "^3.23.8", Workspaces: map[string]string{"ui": "^3.23.8", ".": "^3.22.0"}},
+		{Package: "lodash", Workspaces: map[string]string{"web": ">=4 <5", "docs": "4.x"}, Skipped: "skipped by user"},
+	})
+	if err != nil {
+		t.Fatalf("CatalogConsolidate() error = %v", err)
+	}
+	if got, want := cmd.stdout.String(), "[OK] consolidated zod to ^3.23.8 (.@^3.22.0, ui@^3.23.8)\n"; got != want {
+		t.Fatalf("stdout = %q, want %q", got, want)
+	}
+	if got, want := cmd.stderr.String(), "[WARN] skipped lodash: skipped by user (docs@4.x, web@>=4 <5)\n"; got != want {
+		t.Fatalf("stderr = %q, want %q", got, want)
+	}
+}
+
+func TestCatalogInlineDryRunLines(t *testing.T) {
+	withOutputFormat(t, formatText)
+	withOutputColorMode(t, colorModeNever)
+	withOutputShowLevel(t, true)
+
+	cmd := fakeCommand{ctx: app.WithDryRun(context.Background()), stdout: &bytes.Buffer{}, stderr: &bytes.Buffer{}}
+	err := NewPrinter().CatalogInline(cmd, []app.CatalogInlining{
+		{Package: "react", Version: "^19.0.0", Workspaces: []string{".", "ui"}, Removed: true},
+	})
+	if err != nil {
+		t.Fatalf("CatalogInline() error = %v", err)
+	}
+	want := "[INFO] would inline react@^19.0.0 from default catalog into ., ui\n" +
+		"[INFO] would remove react from default catalog\n"
+	if got := cmd.stdout.String(); got != want {
+		t.Fatalf("stdout = %q, want %q", got, want)
+	}
+}
+
+func TestCatalogSyncWarnsAboutAmbiguousPackages(t *testing.T) {
+	withOutputFormat(t, formatText)
+	withOutputColorMode(t, colorModeNever)
+	withOutputShowLevel(t, true)
+
+	cmd := fakeCommand{ctx: context.Background(), stdout: &bytes.Buffer{}, stderr: &bytes.Buffer{}}
+	err := NewPrinter().CatalogSync(cmd, app.CatalogSyncResult{
+		Catalogs:  []string{"", "react17"},
+		Ambiguous: []app.CatalogAmbiguity{{Package: "react", Catalogs: []string{"", "react17"}}},
+	})
+	if err != nil {
+		t.Fatalf("CatalogSync() error = %v", err)
+	}
+	if cmd.stdout.Len() != 0 {
+		t.Fatalf("stdout = %q, want empty", cmd.stdout.String())
+	}
+	want := "[WARN] skipped react: defined in default, react17 catalogs; reference one explicitly\n"
+	if got := cmd.stderr.String(); got != want {
+		t.Fatalf("stderr = %q, want %q", got, want)
+	}
+}
+
+func TestCatalogDeleteForceLines(t *testing.T) {
+	withOutputFormat(t, formatText)
+	withOutputColorMode(t, colorModeNever)
+	withOutputShowLevel(t, true)
+
+	cmd := fakeCommand{ctx: context.Background(), stdout: &bytes.Buffer{}, stderr: &bytes.Buffer{}}
+	err := NewPrinter().CatalogDelete(cmd, app.CatalogDeletion{Name: "legacy", Entries: 2, Workspaces: []string{".", "ui"}})
+	if err != nil {
+		t.Fatalf("CatalogDelete() error = %v", err)
+	}
+	want := "[OK] inlined legacy catalog references in ., ui\n" +
+		"[OK] deleted legacy catalog (2 entries)\n"
+	if got := cmd.stdout.String(); got != want {
+		t.Fatalf("stdout = %q, want %q", got, want)
+	}
+}
+
+func TestCatalogCheckViolationLines(t *testing.T) {
+	withOutputFormat(t, formatText)
+	withOutputColorMode(t, colorModeNever)
+	withOutputShowLevel(t, true)
+
+	cmd := fakeCommand{ctx: context.Background(), stdout: &bytes.Buffer{}, stderr: &bytes.Buffer{}}
+	err := NewPrinter().CatalogCheck(cmd, []app.CatalogViolation{
+		{Kind: app.ViolationHardcodedRange, Workspace: ".", Bucket: domain.BucketDevDependencies, Package: "zod", Spec: "^3.22.0", Catalogs: []string{"", "v3"}},
+		{Kind: app.ViolationUnknownCatalog, Workspace: "ui", Bucket: domain.BucketDependencies, Package: "clsx", Spec: "catalog:legacy", Catalogs: []string{"legacy"}},
+		{Kind: app.ViolationMissingEntry, Workspace: "ui", Bucket: domain.BucketPeerDependencies, Package: "react-dom", Spec: "catalog:", Catalogs: []string{""}},
+	})
+	if err != nil {
+		t.Fatalf("CatalogCheck() error = %v", err)
+	}
+	want := "[ERROR] . devDependencies: zod declares ^3.22.0 instead of a reference to default or v3 catalog\n" +
+		"[ERROR] ui dependencies: clsx references unknown catalog legacy\n" +
+		"[ERROR] ui peerDependencies: react-dom is missing from default catalog\n"
+	if got := cmd.stdout.String(); got != want {
+		t.Fatalf("stdout = %q, want %q", got, want)
+	}
+}
+
+func TestPresetsTable(t *testing.T) {
+	withOutputFormat(t, formatText)
+
+	cmd := fakeCommand{ctx: context.Background(), stdout: &bytes.Buffer{}, stderr: &bytes.Buffer{}}
+	err := NewPrinter().Presets(cmd, []app.PresetListing{
+		{Name: "empty", Buckets: []app.PresetBucketListing{}},
+		{Name: "lint", Buckets: []app.PresetBucketListing{
+			{Bucket: domain.BucketDependencies, Packages: []string{"zod"}},
+			{Bucket: domain.BucketDevDependencies, Packages: []string{"eslint", "prettier"}},
+		}},
+	})
+	if err != nil {
+		t.Fatalf("Presets() error = %v", err)
+	}
+	want := "PRESET  BUCKET           PACKAGES\n" +
+		"empty   -                -\n" +
+		"lint    dependencies     zod\n" +
+		"lint    devDependencies  eslint, prettier\n"
+	if got := cmd.stdout.String(); got != want {
+		t.Fatalf("stdout =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestPresetSaveTextListsBuckets(t *testing.T) {
+	withOutputFormat(t, formatText)
+	withOutputColorMode(t, colorModeNever)
+	withOutputShowLevel(t, false)
+
+	cmd := fakeCommand{ctx: context.Background(), stdout: &bytes.Buffer{}, stderr: &bytes.Buffer{}}
+	err := NewPrinter().PresetSave(cmd, app.PresetListing{Name: "web", Buckets: []app.PresetBucketListing{
+		{Bucket: domain.BucketDependencies, Packages: []string{"react"}},
+		{Bucket: domain.BucketDevDependencies, Packages: []string{"eslint", "vite"}},
+	}}, "apps/web")
+	if err != nil {
+		t.Fatalf("PresetSave() error = %v", err)
+	}
+	want := "saved web dependencies from apps/web: react\n" +
+		"saved web devDependencies from apps/web: eslint, vite\n"
+	if got := cmd.stdout.String(); got != want {
+		t.Fatalf("stdout = %q, want %q", got, want)
+	}
+}
+
+func TestPresetsTableShowsExtends(t *testing.T) {
+	withOutputFormat(t, formatText)
+
+	cmd := fakeCommand{ctx: context.Background(), stdout: &bytes.Buffer{}, stderr: &bytes.Buffer{}}
+	err := NewPrinter().Presets(cmd, []app.PresetListing{
+		{Name: "alias", Extends: []string{"lint"}, Buckets: []app.PresetBucketListing{}},
+	})
+	if err != nil {
+		t.Fatalf("Presets() error = %v", err)
+	}
+	want := "PRESET  BUCKET   PACKAGES\n" +
+		"alias   extends  lint\n"
+	if got := cmd.stdout.String(); got != want {
+		t.Fatalf("stdout =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestPresetApplyTextListsAppliedBuckets(t *testing.T) {
+	withOutputFormat(t, formatText)
+	withOutputColorMode(t, colorModeNever)
+	withOutputShowLevel(t, false)
+
+	cmd := fakeCommand{ctx: context.Background(), stdout: &bytes.Buffer{}, stderr: &bytes.Buffer{}}
+	err := NewPrinter().PresetApply(cmd, app.PresetApplication{
+		Preset:    "web",
+		Workspace: "apps/web",
+		Applied:   []domain.PresetBucket{domain.BucketDependencies, domain.BucketDevDependencies},
+	})
+	if err != nil {
+		t.Fatalf("PresetApply() error = %v", err)
+	}
+	if got, want := cmd.stdout.String(), "applied web to apps/web: dependencies, devDependencies\n"; got != want {
+		t.Fatalf("stdout = %q, want %q", got, want)
+	}
+}
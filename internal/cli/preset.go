@@ -10,11 +10,13 @@ import (
 
 func newPresetCmd(
 	uc app.PresetUseCase,
+	configUC app.PresetConfigUseCase,
 	completer completion.PresetCompleter,
 	targets completion.TargetCompleter,
 	printer output.Printer,
 ) *cobra.Command {
 	var workspace string
+	var force bool
 
 	cmd := &cobra.Command{
 		Use:   "preset <name> <bucket> [pkg[@version]...]",
@@ -49,12 +51,22 @@ func newPresetCmd(
 				Bucket:    args[1],
 				Packages:  args[2:],
 				Workspace: workspace,
+				Force:     force,
 			})
-			return printer.Handle(cmd.ErrOrStderr(), err)
+			return printer.Handle(cmd, err)
 		},
 	}
 
-	cmd.Flags().StringVar(&workspace, "workspace", "", "Workspace key to install into (default: root)")
+	cmd.AddCommand(newPresetAddCmd(configUC, completer, targets, printer))
+	cmd.AddCommand(newPresetApplyCmd(uc, completer, targets, printer))
+	cmd.AddCommand(newPresetCreateCmd(configUC, printer))
+	cmd.AddCommand(newPresetDeleteCmd(configUC, completer, printer))
+	cmd.AddCommand(newPresetListCmd(configUC, completer, printer))
+	cmd.AddCommand(newPresetRemoveCmd(configUC, completer, printer))
+	cmd.AddCommand(newPresetSaveCmd(configUC, targets, printer))
+
+	cmd.Flags().StringVar(&workspace, "workspace", "", "Workspace key to install into (default: current workspace, else root)")
+	cmd.Flags().BoolVar(&force, "force", false, "Override conflicting existing catalog versions for catalog presets")
 	mustRegisterFlagCompletionFunc(cmd, "workspace", func(cmd *cobra.Command, _ []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 		items, err := targets.WorkspaceKeys(cmd.Context(), toComplete)
 		if err != nil {
@@ -65,3 +77,215 @@ func newPresetCmd(
 
 	return cmd
 }
+
+func newPresetApplyCmd(uc app.PresetUseCase, completer completion.PresetCompleter, targets completion.TargetCompleter, printer output.Printer) *cobra.Command {
+	var workspace string
+	var force bool
+
+	cmd := &cobra.Command{
+		Use:   "apply <name>",
+		Short: "Install every bucket of a configured preset",
+		Args:  cobra.ExactArgs(1),
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			if len(args) > 0 {
+				return nil, cobra.ShellCompDirectiveNoFileComp
+			}
+			items, err := completer.PresetNames(cmd.Context(), toComplete)
+			if err != nil {
+				return nil, cobra.ShellCompDirectiveError
+			}
+			return items, cobra.ShellCompDirectiveNoFileComp
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			result, err := uc.RunApply(cmd.Context(), app.PresetApplyRequest{
+				Preset:    args[0],
+				Workspace: workspace,
+				Force:     force,
+			})
+			if len(result.Applied) > 0 {
+				if printErr := printer.PresetApply(cmd, result); err == nil {
+					err = printErr
+				}
+			}
+			return printer.Handle(cmd, err)
+		},
+	}
+
+	cmd.Flags().StringVar(&workspace, "workspace", "", "Workspace key to install into (default: current workspace, else root)")
+	cmd.Flags().BoolVar(&force, "force", false, "Override conflicting existing catalog versions for catalog presets")
+	mustRegisterFlagCompletionFunc(cmd, "workspace", func(cmd *cobra.Command, _ []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		items, err := targets.WorkspaceKeys(cmd.Context(), toComplete)
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveError
+		}
+		return items, cobra.ShellCompDirectiveNoFileComp
+	})
+
+	return cmd
+}
+
+func newPresetAddCmd(uc app.PresetConfigUseCase, completer completion.PresetCompleter, targets completion.TargetCompleter, printer output.Printer) *cobra.Command {
+	return &cobra.Command{
+		Use:   "add <name> <bucket> <pkg>...",
+		Short: "Add packages to a preset bucket in ordo.json",
+		Args:  cobra.MinimumNArgs(3),
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			var items []string
+			var err error
+			switch len(args) {
+			case 0:
+				items, err = completer.PresetNames(cmd.Context(), toComplete)
+			case 1:
+				items, err = completer.SupportedBuckets(cmd.Context(), toComplete)
+			default:
+				items, err = targets.InstallPackages(cmd.Context(), toComplete)
+				items = filterCompletedArgs(items, args, 2)
+			}
+			if err != nil {
+				return nil, cobra.ShellCompDirectiveError
+			}
+			return items, cobra.ShellCompDirectiveNoFileComp
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			err := uc.RunAdd(cmd.Context(), app.PresetAddRequest{
+				Name:     args[0],
+				Bucket:   args[1],
+				Packages: args[2:],
+			})
+			return printer.Handle(cmd, err)
+		},
+	}
+}
+
+func newPresetCreateCmd(uc app.PresetConfigUseCase, printer output.Printer) *cobra.Command {
+	return &cobra.Command{
+		Use:   "create <name>",
+		Short: "Create an empty preset in ordo.json",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			err := uc.RunCreate(cmd.Context(), app.PresetCreateRequest{Name: args[0]})
+			return printer.Handle(cmd, err)
+		},
+	}
+}
+
+func newPresetDeleteCmd(uc app.PresetConfigUseCase, completer completion.PresetCompleter, printer output.Printer) *cobra.Command {
+	return &cobra.Command{
+		Use:   "delete <name>",
+		Short: "Delete a preset from ordo.json",
+		Args:  cobra.ExactArgs(1),
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			if len(args) > 0 {
+				return nil, cobra.ShellCompDirectiveNoFileComp
+			}
+			items, err := completer.PresetNames(cmd.Context(), toComplete)
+			if err != nil {
+				return nil, cobra.ShellCompDirectiveError
+			}
+			return items, cobra.ShellCompDirectiveNoFileComp
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			err := uc.RunDelete(cmd.Context(), app.PresetDeleteRequest{Name: args[0]})
+			return printer.Handle(cmd, err)
+		},
+	}
+}
+
+func newPresetListCmd(uc app.PresetConfigUseCase, completer completion.PresetCompleter, printer output.Printer) *cobra.Command {
+	return &cobra.Command{
+		Use:   "list [name]...",
+		Short: "List presets with their buckets and packages",
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			items, err := completer.PresetNames(cmd.Context(), toComplete)
+			if err != nil {
+				return nil, cobra.ShellCompDirectiveError
+			}
+			items = filterCompletedArgs(items, args, 0)
+			return items, cobra.ShellCompDirectiveNoFileComp
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			listings, err := uc.RunList(cmd.Context(), app.PresetListRequest{Names: args})
+			if err == nil {
+				err = printer.Presets(cmd, listings)
+			}
+			return printer.Handle(cmd, err)
+		},
+	}
+}
+
+func newPresetRemoveCmd(uc app.PresetConfigUseCase, completer completion.PresetCompleter, printer output.Printer) *cobra.Command {
+	return &cobra.Command{
+		Use:   "remove <name> <bucket> <pkg>...",
+		Short: "Remove packages from a preset bucket in ordo.json",
+		Args:  cobra.MinimumNArgs(3),
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			var items []string
+			var err error
+			switch len(args) {
+			case 0:
+				items, err = completer.PresetNames(cmd.Context(), toComplete)
+			case 1:
+				items, err = completer.Buckets(cmd.Context(), args[0], toComplete)
+			default:
+				items, err = completer.BucketPackages(cmd.Context(), args[0], args[1], toComplete)
+				items = filterCompletedArgs(items, args, 2)
+			}
+			if err != nil {
+				return nil, cobra.ShellCompDirectiveError
+			}
+			return items, cobra.ShellCompDirectiveNoFileComp
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			err := uc.RunRemove(cmd.Context(), app.PresetRemoveRequest{
+				Name:     args[0],
+				Bucket:   args[1],
+				Packages: args[2:],
+			})
+			return printer.Handle(cmd, err)
+		},
+	}
+}
+
+func newPresetSaveCmd(uc app.PresetConfigUseCase, targets completion.TargetCompleter, printer output.Printer) *cobra.Command {
+	var workspace string
+	var include []string
+	var exclude []string
+	var force bool
+
+	cmd := &cobra.Command{
+		Use:   "save <name>",
+		Short: "Save a workspace's dependencies as a preset in ordo.json",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			listing, err := uc.RunSave(cmd.Context(), app.PresetSaveRequest{
+				Name:      args[0],
+				Workspace: workspace,
+				Include:   include,
+				Exclude:   exclude,
+				Force:     force,
+			})
+			if err == nil {
+				source := workspace
+				if source == "" {
+					source = "current workspace"
+				}
+				err = printer.PresetSave(cmd, listing, source)
+			}
+			return printer.Handle(cmd, err)
+		},
+	}
+
+	cmd.Flags().StringVar(&workspace, "from-workspace", "", "Workspace key to read dependencies from (default: current workspace, else root)")
+	cmd.Flags().StringSliceVar(&include, "include", nil, "Only save packages matching a glob")
+	cmd.Flags().StringSliceVar(&exclude, "exclude", nil, "Skip packages matching a glob")
+	cmd.Flags().BoolVar(&force, "force", false, "Replace an existing preset with the same name")
+	mustRegisterFlagCompletionFunc(cmd, "from-workspace", func(cmd *cobra.Command, _ []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		items, err := targets.WorkspaceKeys(cmd.Context(), toComplete)
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveError
+		}
+		return items, cobra.ShellCompDirectiveNoFileComp
+	})
+
+	return cmd
+}
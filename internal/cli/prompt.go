@@ -0,0 +1,70 @@
+package cli
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"ordo/internal/domain"
+)
+
+// rangePrompt asks on the terminal which version range to keep for a package.
+type rangePrompt struct {
+	in  *bufio.Reader
+	out io.Writer
+}
+
+func newRangePrompt(in io.Reader, out io.Writer) *rangePrompt {
+	return &rangePrompt{in: bufio.NewReader(in), out: out}
+}
+
+func (p *rangePrompt) ChooseRange(_ context.Context, pkg string, options []string, proposed string) (string, error) {
+	fmt.Fprintf(p.out, "%s has differing ranges:\n", pkg)
+	defaultChoice := ""
+	for i, option := range options {
+		marker := ""
+		if option == proposed {
+			marker = " (proposed)"
+			defaultChoice = strconv.Itoa(i + 1)
+		}
+		fmt.Fprintf(p.out, "  %d) %s%s\n", i+1, option, marker)
+	}
+
+	for {
+		if defaultChoice != "" {
+			fmt.Fprintf(p.out, "Choose a range [%s], a custom range, or s to skip: ", defaultChoice)
+		} else {
+			fmt.Fprint(p.out, "Choose a range, a custom range, or s to skip: ")
+		}
+
+		line, err := p.in.ReadString('\n')
+		answer := strings.TrimSpace(line)
+		if err != nil && (err != io.EOF || answer == "") {
+			return "", fmt.Errorf("read range choice for %s: %w", pkg, err)
+		}
+
+		switch {
+		case answer == "" && defaultChoice != "":
+			return proposed, nil
+		case answer == "":
+			continue
+		case strings.EqualFold(answer, "s"):
+			return "", nil
+		}
+		if n, convErr := strconv.Atoi(answer); convErr == nil {
+			if n >= 1 && n <= len(options) {
+				return options[n-1], nil
+			}
+			fmt.Fprintf(p.out, "pick a number between 1 and %d\n", len(options))
+			continue
+		}
+		if _, err := domain.ParseRange(answer); err != nil {
+			fmt.Fprintf(p.out, "%v\n", err)
+			continue
+		}
+		return answer, nil
+	}
+}
@@ -0,0 +1,58 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestRangePromptChoices(t *testing.T) {
+	options := []string{"^3.22.0", "^3.23.8"}
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{name: "default", input: "\n", want: "^3.23.8"},
+		{name: "number", input: "1\n", want: "^3.22.0"},
+		{name: "out of range then number", input: "9\n2\n", want: "^3.23.8"},
+		{name: "custom", input: "~3.23.0\n", want: "~3.23.0"},
+		{name: "invalid custom then number", input: "^3.23.\n2\n", want: "^3.23.8"},
+		{name: "skip", input: "s\n", want: ""},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			out := &bytes.Buffer{}
+			got, err := newRangePrompt(strings.NewReader(tc.input), out).ChooseRange(context.Background(), "zod", options, "^3.23.8")
+			if err != nil {
+				t.Fatalf("ChooseRange() error = %v", err)
+			}
+			if got != tc.want {
+				t.Fatalf("ChooseRange() = %q, want %q", got, tc.want)
+			}
+			if !strings.Contains(out.String(), "  2) ^3.23.8 (proposed)\n") {
+				t.Fatalf("prompt = %q, want proposed marker", out.String())
+			}
+		})
+	}
+}
+
+func TestRangePromptRejectsInvalidCustomRange(t *testing.T) {
+	out := &bytes.Buffer{}
+	_, err := newRangePrompt(strings.NewReader("^1.2.\n"), out).ChooseRange(context.Background(), "zod", []string{"^1.0.0"}, "^1.0.0")
+	if err == nil {
+		t.Fatal("ChooseRange() error = nil, want EOF error after the invalid range")
+	}
+	if !strings.Contains(out.String(), `invalid version range "^1.2."`) {
+		t.Fatalf("prompt = %q, want invalid range message", out.String())
+	}
+}
+
+func TestRangePromptEOF(t *testing.T) {
+	_, err := newRangePrompt(strings.NewReader(""), &bytes.Buffer{}).ChooseRange(context.Background(), "zod", []string{"^1.0.0"}, "^1.0.0")
+	if err == nil {
+		t.Fatal("ChooseRange() error = nil, want EOF error")
+	}
+}
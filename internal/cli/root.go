@@ -3,6 +3,7 @@ package cli
 import (
 	"errors"
 	"os"
+	"path/filepath"
 
 	catalogadapter "ordo/internal/adapters/catalog"
 	execadapter "ordo/internal/adapters/exec"
@@ -12,24 +13,25 @@ import (
 	"ordo/internal/cli/completion"
 	"ordo/internal/cli/output"
 	"ordo/internal/config"
+	"ordo/internal/domain"
 
 	"github.com/spf13/cobra"
 )
 
 func NewRootCmd() (*cobra.Command, error) {
-	cwd, err := config.WorkingDir()
-	if err != nil {
-		return nil, err
-	}
+	// Adapters work with paths relative to the project root. PersistentPreRunE
+	// points the indexer, the config store, and the runner at the detected root.
+	const projectDir = "."
 
-	indexer := fsadapter.NewWorkspaceIndexer(cwd)
-	discovery := app.NewDiscoveryService(indexer)
-	runner := execadapter.NewRunner()
+	indexer := fsadapter.NewWorkspaceIndexer(projectDir)
+	rootedStore := fsadapter.NewRootedConfigStore(fsadapter.NewConfigStore())
+	configStore := fsadapter.NewOverlayConfigStore(rootedStore)
+	discovery := app.NewDiscoveryServiceWithConfig(&indexer, configStore, projectDir, output.NewNotifier(os.Stderr))
+	runner := &reportingRunner{Runner: execadapter.NewRunnerWithStdout(output.CommandOutput)}
 	suggestor := registryadapter.NewNPMSuggestor()
 	printer := output.NewPrinter()
-	configStore := fsadapter.NewConfigStore()
-	catalogStore := catalogadapter.NewStore(cwd, configStore)
-	manifestStore := catalogadapter.NewManifestStore(cwd, configStore)
+	catalogStore := catalogadapter.NewStore(projectDir, configStore)
+	manifestStore := catalogadapter.NewManifestStore(projectDir, configStore)
 	installCompletion := app.NewInstallCompletionService(discovery, suggestor)
 	completer := completion.NewTargetCompleter(discovery, installCompletion)
 	globalCompletion := app.NewGlobalCompletionService(installCompletion, runner, runner)
@@ -39,7 +41,7 @@ func NewRootCmd() (*cobra.Command, error) {
 	catalogCompletion := app.NewCatalogCompletionService(discovery, installCompletion, catalogStore)
 	catalogCompleter := completion.NewCatalogCompleter(catalogCompletion)
 
-	runUC := app.NewRunUseCase(discovery, runner)
+	runUC := app.NewRunUseCaseWithStreams(discovery, runner, runner)
 	installUC := app.NewInstallUseCase(discovery, runner)
 	uninstallUC := app.NewUninstallUseCase(discovery, runner)
 	updateUC := app.NewUpdateUseCase(discovery, runner)
@@ -47,10 +49,17 @@ func NewRootCmd() (*cobra.Command, error) {
 	globalUninstallUC := app.NewGlobalUninstallUseCase(runner, runner)
 	globalUpdateUC := app.NewGlobalUpdateUseCase(runner)
 	initUC := app.NewInitUseCase(configStore)
-	presetUC := app.NewPresetUseCase(discovery, runner, configStore)
 	catalogUC := app.NewCatalogUseCaseWithConfig(discovery, catalogStore, manifestStore, registryadapter.NewNPMLatestResolver(), configStore)
+	presetUC := app.NewPresetUseCaseWithCatalogs(discovery, runner, configStore, catalogUC)
+	presetConfigUC := app.NewPresetConfigUseCase(discovery, configStore)
 	var colorFlag string
 	var noLevelFlag bool
+	var cwdFlag string
+	var rootFlag string
+	var managerFlag string
+	var outputFlag string
+	var jsonFlag bool
+	var dryRunFlag bool
 
 	cmd := &cobra.Command{
 		Use:           "ordo",
@@ -75,13 +84,52 @@ func NewRootCmd() (*cobra.Command, error) {
 				showLevel = !noLevelFlag
 			}
 
+			if jsonFlag {
+				outputFlag = "json"
+			}
+			format, err := output.ParseOutputFormat(outputFlag)
+			if err != nil {
+				return err
+			}
+
 			output.SetOutputColorMode(mode)
 			output.SetOutputShowLevel(showLevel)
+			output.SetOutputFormat(format)
+
+			root, invocationDir, err := resolveProjectRoot(cwdFlag, rootFlag)
+			if err != nil {
+				return err
+			}
+			indexer = fsadapter.NewWorkspaceIndexer(root)
+			rootedStore.SetRoot(root)
+			runner.root = root
+
+			report := &app.Report{}
+			ctx := app.WithReport(cmd.Context(), report)
+			ctx = app.WithInvocationDir(ctx, invocationDir)
+			if dryRunFlag {
+				configStore.Enable(report)
+				ctx = app.WithDryRun(ctx)
+			}
+			if managerFlag != "" {
+				manager, err := domain.ParsePackageManager(managerFlag)
+				if err != nil {
+					return err
+				}
+				ctx = app.WithManagerOverride(ctx, manager)
+			}
+			cmd.SetContext(ctx)
 			return nil
 		},
 	}
 	cmd.PersistentFlags().StringVar(&colorFlag, "color", "auto", "Colorize output: auto, always, never")
 	cmd.PersistentFlags().BoolVar(&noLevelFlag, "no-level", false, "Hide output level labels (INFO, OK, WARN, ERROR)")
+	cmd.PersistentFlags().StringVar(&outputFlag, "output", "text", "Output format: text, json")
+	cmd.PersistentFlags().BoolVar(&jsonFlag, "json", false, "Shorthand for --output json")
+	cmd.PersistentFlags().BoolVar(&dryRunFlag, "dry-run", false, "Print the commands and file changes instead of applying them")
+	cmd.PersistentFlags().StringVar(&cwdFlag, "cwd", "", "Run as if ordo was started in this directory")
+	cmd.PersistentFlags().StringVar(&managerFlag, "manager", "", "Use this package manager instead of detecting it: npm, pnpm, yarn, bun")
+	cmd.PersistentFlags().StringVar(&rootFlag, "root", "", "Use this directory as the project root instead of detecting it")
 
 	cmd.AddCommand(newRunCmd(runUC, completer, printer))
 	cmd.AddCommand(newInstallCmd(installUC, completer, printer))
@@ -89,13 +137,47 @@ func NewRootCmd() (*cobra.Command, error) {
 	cmd.AddCommand(newUpdateCmd(updateUC, completer, printer))
 	cmd.AddCommand(newGlobalCmd(globalInstallUC, globalUninstallUC, globalUpdateUC, globalCompleter, printer))
 	cmd.AddCommand(newInitCmd(initUC, globalCompleter, printer))
-	cmd.AddCommand(newPresetCmd(presetUC, presetCompleter, completer, printer))
+	cmd.AddCommand(newPresetCmd(presetUC, presetConfigUC, presetCompleter, completer, printer))
 	cmd.AddCommand(newCatalogCmd(catalogUC, catalogCompleter, presetCompleter, printer))
 	cmd.AddCommand(newCatalogsCmd(catalogUC, catalogCompleter, printer))
 
 	return cmd, nil
 }
 
+// resolveProjectRoot returns the absolute project root and the invocation
+// directory relative to it. The root is rootFlag when set, otherwise the nearest
+// ancestor of the invocation directory that looks like a monorepo root.
+func resolveProjectRoot(cwdFlag string, rootFlag string) (string, string, error) {
+	start, err := config.WorkingDir()
+	if err != nil {
+		return "", "", err
+	}
+	if cwdFlag != "" {
+		start, err = filepath.Abs(cwdFlag)
+		if err != nil {
+			return "", "", err
+		}
+	}
+
+	root := rootFlag
+	if root == "" {
+		root, err = fsadapter.FindProjectRoot(start)
+		if err != nil {
+			return "", "", err
+		}
+	}
+	root, err = filepath.Abs(root)
+	if err != nil {
+		return "", "", err
+	}
+
+	rel, err := filepath.Rel(root, start)
+	if err != nil {
+		return root, ".", nil
+	}
+	return root, rel, nil
+}
+
 func Execute() int {
 	cmd, err := NewRootCmd()
 	if err != nil {
@@ -112,6 +194,10 @@ func Execute() int {
 	if errors.As(err, &exitErr) {
 		return exitErr.Code
 	}
-	_ = output.PrintRootError(os.Stderr, err)
+	// Errors raised before RunE, such as flag validation, still go through the
+	// printer so JSON output stays well-formed.
+	if errors.As(output.NewPrinter().Handle(cmd, err), &exitErr) {
+		return exitErr.Code
+	}
 	return 1
 }
@@ -2,20 +2,28 @@ package cli
 
 import (
 	"bytes"
+	"encoding/json"
 	"os"
 	"path/filepath"
+	"reflect"
 	"runtime"
+	"sort"
 	"strings"
 	"testing"
 
 	"github.com/spf13/cobra"
 	"ordo/internal/cli/output"
+	"ordo/internal/domain"
 )
 
 func withRootOutputDefaults(t *testing.T) {
 	t.Helper()
 	output.SetOutputColorMode("auto")
 	output.SetOutputShowLevel(true)
+	output.SetOutputFormat("text")
+	t.Cleanup(func() {
+		output.SetOutputFormat("text")
+	})
 }
 
 func newTestRootCmd(t *testing.T) (*cobra.Command, *bytes.Buffer) {
@@ -101,6 +109,187 @@ func TestRootNoLevelFlagTrueOverridesEnvFalse(t *testing.T) {
 	}
 }
 
+func TestResolveProjectRootKeepsWorkingDirectory(t *testing.T) {
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	root, err := os.MkdirTemp(wd, "project-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_ = os.RemoveAll(root)
+	})
+	files := map[string]string{
+		"package.json":             `{"name":"root","workspaces":["packages/*"]}`,
+		"pnpm-lock.yaml":           "",
+		"packages/ui/package.json": `{"name":"@acme/ui"}`,
+	}
+	for name, content := range files {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	gotRoot, gotDir, err := resolveProjectRoot(filepath.Join(filepath.Base(root), "packages", "ui"), "")
+	if err != nil {
+		t.Fatalf("resolveProjectRoot() error = %v", err)
+	}
+	if gotRoot != root {
+		t.Fatalf("root = %q, want %q", gotRoot, root)
+	}
+	if want := filepath.Join("packages", "ui"); gotDir != want {
+		t.Fatalf("invocation dir = %q, want %q", gotDir, want)
+	}
+	if after, err := os.Getwd(); err != nil || after != wd {
+		t.Fatalf("working directory = %q, %v, want %q", after, err, wd)
+	}
+}
+
+func TestRootJSONFlagEmitsErrorDocument(t *testing.T) {
+	cmd, buf := newTestRootCmd(t)
+	cmd.SetArgs([]string{"--json", "run", "   "})
+
+	if err := cmd.Execute(); err == nil {
+		t.Fatal("Execute() error = nil, want non-nil")
+	}
+
+	var result struct {
+		OK    bool `json:"ok"`
+		Error struct {
+			Code     string `json:"code"`
+			ExitCode int    `json:"exitCode"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &result); err != nil {
+		t.Fatalf("output = %q, want a JSON document: %v", buf.String(), err)
+	}
+	if result.OK || result.Error.Code != "invalid_target" || result.Error.ExitCode != 2 {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+}
+
+func TestRootRejectsInvalidOutputFlagValue(t *testing.T) {
+	cmd, _ := newTestRootCmd(t)
+	cmd.SetArgs([]string{"--output=yaml", "run", "script"})
+
+	err := cmd.Execute()
+	if err == nil || !strings.Contains(err.Error(), `invalid value for --output: "yaml"`) {
+		t.Fatalf("error = %v, want invalid --output message", err)
+	}
+}
+
+func TestRootDryRunPrintsCommandsWithoutRunning(t *testing.T) {
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, "package.json"), []byte(`{"name":"root"}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "pnpm-lock.yaml"), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	cmd, buf := newTestRootCmd(t)
+	cmd.SetArgs([]string{"--root", root, "--dry-run", "install", "zod"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if got, want := buf.String(), "[INFO] would run in .: pnpm add zod\n"; got != want {
+		t.Fatalf("output = %q, want %q", got, want)
+	}
+}
+
+func TestRootCatalogPruneDryRunLeavesCatalogUntouched(t *testing.T) {
+	root := t.TempDir()
+	manifest := `{"name":"root","dependencies":{"react":"catalog:"}}`
+	workspace := "packages: []\ncatalog:\n  react: ^19.0.0\n  zod: ^3.23.0\n"
+	if err := os.WriteFile(filepath.Join(root, "package.json"), []byte(manifest), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "pnpm-lock.yaml"), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "pnpm-workspace.yaml"), []byte(workspace), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	cmd, buf := newTestRootCmd(t)
+	cmd.SetArgs([]string{"--root", root, "--dry-run", "catalog", "prune"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	got := buf.String()
+	if !strings.HasPrefix(got, "[INFO] would prune zod@^3.23.0 from default catalog\n") {
+		t.Fatalf("output = %q, want prune listing first", got)
+	}
+	if !strings.Contains(got, "-  zod: ^3.23.0\n") {
+		t.Fatalf("output = %q, want pnpm-workspace.yaml diff", got)
+	}
+	data, err := os.ReadFile(filepath.Join(root, "pnpm-workspace.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != workspace {
+		t.Fatalf("pnpm-workspace.yaml = %q, want unchanged", data)
+	}
+}
+
+func TestRootResolvesRelativeCwdWithoutChangingDirectory(t *testing.T) {
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	root, err := os.MkdirTemp(wd, "project-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_ = os.RemoveAll(root)
+	})
+	files := map[string]string{
+		"package.json":             `{"name":"root","workspaces":["packages/*"]}`,
+		"pnpm-lock.yaml":           "",
+		"packages/ui/package.json": `{"name":"@acme/ui"}`,
+	}
+	for name, content := range files {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cmd, buf := newTestRootCmd(t)
+	cmd.SetArgs([]string{"--cwd", filepath.Join(filepath.Base(root), "packages", "ui"), "--dry-run", "install", "zod"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if got, want := buf.String(), "[INFO] would run in packages/ui: pnpm add zod\n"; got != want {
+		t.Fatalf("output = %q, want %q", got, want)
+	}
+	if after, err := os.Getwd(); err != nil || after != wd {
+		t.Fatalf("working directory = %q, %v, want %q", after, err, wd)
+	}
+}
+
+func TestRootCatalogUpgradeRejectsMultipleLevels(t *testing.T) {
+	cmd, _ := newTestRootCmd(t)
+	cmd.SetArgs([]string{"catalog", "upgrade", "--minor", "--patch"})
+
+	err := cmd.Execute()
+	if err == nil || !strings.Contains(err.Error(), "none of the others can be") {
+		t.Fatalf("Execute() error = %v, want mutually exclusive flag error", err)
+	}
+}
+
 func TestRootRegistersGlobalSubcommands(t *testing.T) {
 	cmd, _ := newTestRootCmd(t)
 
@@ -216,6 +405,32 @@ func TestRootRegistersCatalogCommand(t *testing.T) {
 		t.Fatalf("expected catalog sync command, got %#v", syncCmd)
 	}
 
+	listCmd, _, err := cmd.Find([]string{"catalog", "list"})
+	if err != nil {
+		t.Fatalf("Find(catalog list) error = %v", err)
+	}
+	if listCmd == nil || listCmd.Name() != "list" {
+		t.Fatalf("expected catalog list command, got %#v", listCmd)
+	}
+
+	for _, name := range []string{"check", "consolidate", "inline", "migrate", "outdated", "upgrade"} {
+		sub, _, err := cmd.Find([]string{"catalog", name})
+		if err != nil {
+			t.Fatalf("Find(catalog %s) error = %v", name, err)
+		}
+		if sub == nil || sub.Name() != name {
+			t.Fatalf("expected catalog %s command, got %#v", name, sub)
+		}
+	}
+
+	pruneCmd, _, err := cmd.Find([]string{"catalog", "prune"})
+	if err != nil {
+		t.Fatalf("Find(catalog prune) error = %v", err)
+	}
+	if pruneCmd == nil || pruneCmd.Name() != "prune" {
+		t.Fatalf("expected catalog prune command, got %#v", pruneCmd)
+	}
+
 	presetsCmd, _, err := cmd.Find([]string{"catalog", "presets"})
 	if err != nil {
 		t.Fatalf("Find(catalog presets) error = %v", err)
@@ -225,6 +440,40 @@ func TestRootRegistersCatalogCommand(t *testing.T) {
 	}
 }
 
+func TestRootRegistersPresetSubcommands(t *testing.T) {
+	cmd, _ := newTestRootCmd(t)
+
+	var subcommands []string
+	for _, name := range []string{"add", "apply", "create", "delete", "list", "remove", "save"} {
+		subcommands = append(subcommands, name)
+		sub, _, err := cmd.Find([]string{"preset", name})
+		if err != nil {
+			t.Fatalf("Find(preset %s) error = %v", name, err)
+		}
+		if sub == nil || sub.Name() != name {
+			t.Fatalf("expected preset %s command, got %#v", name, sub)
+		}
+	}
+
+	preset, _, _ := cmd.Find([]string{"preset"})
+	registered := []string{}
+	for _, sub := range preset.Commands() {
+		registered = append(registered, sub.Name())
+	}
+	sort.Strings(registered)
+	if reserved := domain.ReservedPresetNames(); !reflect.DeepEqual(registered, reserved) || !reflect.DeepEqual(subcommands, reserved) {
+		t.Fatalf("preset subcommands %v must match reserved preset names %v", registered, reserved)
+	}
+
+	install, args, err := cmd.Find([]string{"preset", "prettier", "devDependencies"})
+	if err != nil {
+		t.Fatalf("Find(preset prettier devDependencies) error = %v", err)
+	}
+	if install == nil || install.Name() != "preset" || len(args) != 2 {
+		t.Fatalf("expected preset install with args, got %#v %v", install, args)
+	}
+}
+
 func TestRootRegistersCatalogsCommand(t *testing.T) {
 	cmd, _ := newTestRootCmd(t)
 
@@ -244,6 +493,22 @@ func TestRootRegistersCatalogsCommand(t *testing.T) {
 		t.Fatalf("expected catalogs add command, got %#v", addCmd)
 	}
 
+	listCmd, _, err := cmd.Find([]string{"catalogs", "list"})
+	if err != nil {
+		t.Fatalf("Find(catalogs list) error = %v", err)
+	}
+	if listCmd == nil || listCmd.Name() != "list" {
+		t.Fatalf("expected catalogs list command, got %#v", listCmd)
+	}
+
+	pruneCmd, _, err := cmd.Find([]string{"catalogs", "prune"})
+	if err != nil {
+		t.Fatalf("Find(catalogs prune) error = %v", err)
+	}
+	if pruneCmd == nil || pruneCmd.Name() != "prune" {
+		t.Fatalf("expected catalogs prune command, got %#v", pruneCmd)
+	}
+
 	removeCmd, _, err := cmd.Find([]string{"catalogs", "remove"})
 	if err != nil {
 		t.Fatalf("Find(catalogs remove) error = %v", err)
@@ -251,6 +516,16 @@ func TestRootRegistersCatalogsCommand(t *testing.T) {
 	if removeCmd == nil || removeCmd.Name() != "remove" {
 		t.Fatalf("expected catalogs remove command, got %#v", removeCmd)
 	}
+
+	for _, name := range []string{"copy", "delete", "rename", "sync"} {
+		sub, _, err := cmd.Find([]string{"catalogs", name})
+		if err != nil {
+			t.Fatalf("Find(catalogs %s) error = %v", name, err)
+		}
+		if sub == nil || sub.Name() != name {
+			t.Fatalf("expected catalogs %s command, got %#v", name, sub)
+		}
+	}
 }
 
 func TestInitRequiresDefaultPackageManagerFlag(t *testing.T) {
@@ -1,6 +1,9 @@
 package cli
 
 import (
+	"fmt"
+	"os"
+
 	"ordo/internal/app"
 	"ordo/internal/cli/completion"
 	"ordo/internal/cli/output"
@@ -9,6 +12,11 @@ import (
 )
 
 func newRunCmd(uc app.RunUseCase, completer completion.TargetCompleter, printer output.Printer) *cobra.Command {
+	var all bool
+	var filters []string
+	var parallel bool
+	var concurrency int
+
 	cmd := &cobra.Command{
 		Use:   "run <target> [-- <args...>]",
 		Short: "Run a package script in root or workspace",
@@ -17,18 +25,53 @@ func newRunCmd(uc app.RunUseCase, completer completion.TargetCompleter, printer
 			if len(args) > 0 {
 				return nil, cobra.ShellCompDirectiveNoFileComp
 			}
-			items, err := completer.ScriptTargets(cmd.Context(), toComplete)
+			var items []string
+			var err error
+			if all || len(filters) > 0 {
+				items, err = completer.WorkspaceScripts(cmd.Context(), toComplete)
+			} else {
+				items, err = completer.ScriptTargets(cmd.Context(), toComplete)
+			}
 			if err != nil {
 				return nil, cobra.ShellCompDirectiveError
 			}
 			return items, cobra.ShellCompDirectiveNoFileComp
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if (parallel || cmd.Flags().Changed("concurrency")) && !all && len(filters) == 0 {
+				return printer.Handle(cmd, fmt.Errorf("--parallel and --concurrency require --all or --filter"))
+			}
+			if cmd.Flags().Changed("concurrency") {
+				parallel = true
+			}
+			if all || len(filters) > 0 {
+				err := uc.RunMany(cmd.Context(), app.RunManyRequest{
+					Script:      args[0],
+					All:         all,
+					Filters:     filters,
+					ExtraArgs:   trailingArgs(args),
+					Parallel:    parallel,
+					Concurrency: concurrency,
+					Prefix:      output.WorkspacePrefixer(output.CommandOutput(), os.Stderr),
+				})
+				return printer.Handle(cmd, err)
+			}
 			err := uc.Run(cmd.Context(), app.RunRequest{Target: args[0], ExtraArgs: trailingArgs(args)})
-			return printer.Handle(cmd.ErrOrStderr(), err)
+			return printer.Handle(cmd, err)
 		},
 	}
 	cmd.DisableFlagParsing = false
+	cmd.Flags().BoolVar(&all, "all", false, "Run the script in every workspace that defines it, in dependency order")
+	cmd.Flags().StringSliceVar(&filters, "filter", nil, "Run the script in workspaces matching a glob (key, directory, or package name)")
+	cmd.Flags().BoolVar(&parallel, "parallel", false, "Run independent workspaces concurrently with prefixed output")
+	cmd.Flags().IntVar(&concurrency, "concurrency", 0, "Maximum concurrent scripts; implies --parallel (default: number of CPUs)")
+	mustRegisterFlagCompletionFunc(cmd, "filter", func(cmd *cobra.Command, _ []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		items, err := completer.WorkspaceKeys(cmd.Context(), toComplete)
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveError
+		}
+		return items, cobra.ShellCompDirectiveNoFileComp
+	})
 	return cmd
 }
 
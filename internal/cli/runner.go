@@ -0,0 +1,59 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+
+	execadapter "ordo/internal/adapters/exec"
+	"ordo/internal/app"
+	"ordo/internal/ports"
+)
+
+// reportingRunner records every command it runs in the invocation's report. In a
+// dry run it only records them. Commands run in dir resolved against root,
+// while the report keeps dir as given.
+type reportingRunner struct {
+	execadapter.Runner
+	root string
+}
+
+func (r reportingRunner) Run(ctx context.Context, dir string, argv []string) error {
+	if app.IsDryRun(ctx) {
+		app.ReportFrom(ctx).RecordCommand(dir, argv, 0)
+		return nil
+	}
+	err := r.Runner.Run(ctx, r.resolve(dir), argv)
+	app.ReportFrom(ctx).RecordCommand(dir, argv, commandExitCode(err))
+	return err
+}
+
+func (r reportingRunner) RunPrefixed(ctx context.Context, dir string, argv []string, prefix ports.StreamPrefix) error {
+	if app.IsDryRun(ctx) {
+		app.ReportFrom(ctx).RecordCommand(dir, argv, 0)
+		return nil
+	}
+	err := r.Runner.RunPrefixed(ctx, r.resolve(dir), argv, prefix)
+	app.ReportFrom(ctx).RecordCommand(dir, argv, commandExitCode(err))
+	return err
+}
+
+func (r reportingRunner) resolve(dir string) string {
+	if r.root == "" || filepath.IsAbs(dir) {
+		return dir
+	}
+	return filepath.Join(r.root, dir)
+}
+
+// commandExitCode returns the exit status of a finished command, or -1 when it
+// could not be started.
+func commandExitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+	var exitStatus interface{ ExitCode() int }
+	if errors.As(err, &exitStatus) {
+		return exitStatus.ExitCode()
+	}
+	return -1
+}
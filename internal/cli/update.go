@@ -25,7 +25,7 @@ func newUpdateCmd(uc app.UpdateUseCase, completer completion.TargetCompleter, pr
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			err := uc.Run(cmd.Context(), app.UpdateRequest{Target: args[0]})
-			return printer.Handle(cmd.ErrOrStderr(), err)
+			return printer.Handle(cmd, err)
 		},
 	}
 }
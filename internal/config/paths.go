@@ -37,3 +37,8 @@ func OrdoConfigPath() (string, error) {
 	}
 	return filepath.Join(configDir, "ordo.json"), nil
 }
+
+// ProjectConfigPath returns the project-level ordo.json inside a project root.
+func ProjectConfigPath(root string) string {
+	return filepath.Join(root, "ordo.json")
+}
@@ -54,6 +54,21 @@ func CatalogReference(name string) string {
 	return "catalog:" + trimmed
 }
 
+// ParseCatalogReference reports whether version is a catalog reference and, if
+// so, which catalog it names. The default catalog, including the explicit
+// "catalog:default" form, is returned as an empty name.
+func ParseCatalogReference(version string) (string, bool) {
+	value := strings.TrimSpace(version)
+	if !strings.HasPrefix(value, "catalog:") {
+		return "", false
+	}
+	name := strings.TrimSpace(strings.TrimPrefix(value, "catalog:"))
+	if name == "default" {
+		name = ""
+	}
+	return name, true
+}
+
 func ValidateCatalogName(raw string) error {
 	name := strings.TrimSpace(raw)
 	if name == "" {
@@ -61,6 +61,27 @@ func TestCatalogReference(t *testing.T) {
 	}
 }
 
+func TestParseCatalogReference(t *testing.T) {
+	tests := []struct {
+		in       string
+		wantName string
+		wantOK   bool
+	}{
+		{in: "catalog:", wantName: "", wantOK: true},
+		{in: "catalog:default", wantName: "", wantOK: true},
+		{in: " catalog:react19 ", wantName: "react19", wantOK: true},
+		{in: "^18.0.0", wantName: "", wantOK: false},
+		{in: "workspace:*", wantName: "", wantOK: false},
+	}
+
+	for _, tc := range tests {
+		name, ok := ParseCatalogReference(tc.in)
+		if name != tc.wantName || ok != tc.wantOK {
+			t.Fatalf("ParseCatalogReference(%q) = %q, %v; want %q, %v", tc.in, name, ok, tc.wantName, tc.wantOK)
+		}
+	}
+}
+
 func TestValidateCatalogName(t *testing.T) {
 	if err := ValidateCatalogName("react_19"); err != nil {
 		t.Fatalf("unexpected error: %v", err)
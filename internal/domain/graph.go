@@ -0,0 +1,155 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+var ErrDependencyCycle = errors.New("workspace dependency cycle")
+
+// WorkspaceGraph links workspaces to the other workspaces they depend on.
+// A dependency is internal when its name matches another workspace's package
+// name and its spec resolves to that workspace: a workspace: range, or a file:,
+// link: or portal: path to the workspace's directory. A sibling pinned to a
+// registry range is installed from the registry and adds no edge.
+type WorkspaceGraph struct {
+	keys []string
+	deps map[string][]string
+}
+
+func NewWorkspaceGraph(workspaces map[string]PackageInfo) WorkspaceGraph {
+	byName := make(map[string]string, len(workspaces))
+	for key, pkg := range workspaces {
+		if pkg.Name != "" {
+			byName[pkg.Name] = key
+		}
+	}
+
+	keys := make([]string, 0, len(workspaces))
+	deps := make(map[string][]string, len(workspaces))
+	for key, pkg := range workspaces {
+		keys = append(keys, key)
+		local := make([]string, 0)
+		for dep, spec := range pkg.DependencyVersions {
+			target, ok := byName[dep]
+			if !ok || target == key || !localDependency(spec, pkg.Dir, workspaces[target].Dir) {
+				continue
+			}
+			local = append(local, target)
+		}
+		sort.Strings(local)
+		deps[key] = local
+	}
+	sort.Strings(keys)
+
+	return WorkspaceGraph{keys: keys, deps: deps}
+}
+
+// localDependency reports whether spec, declared by the workspace in from,
+// resolves to the workspace in dir rather than to a registry release.
+func localDependency(spec string, from string, dir string) bool {
+	spec = strings.TrimSpace(spec)
+	if strings.HasPrefix(spec, "workspace:") {
+		return true
+	}
+	for _, protocol := range []string{"file:", "link:", "portal:"} {
+		target, ok := strings.CutPrefix(spec, protocol)
+		if !ok {
+			continue
+		}
+		target = filepath.ToSlash(target)
+		if target == "" || path.IsAbs(target) {
+			return false
+		}
+		return path.Join(from, target) == path.Clean(dir)
+	}
+	return false
+}
+
+// Dependencies returns the workspace keys that key depends on directly.
+func (g WorkspaceGraph) Dependencies(key string) []string {
+	return g.deps[key]
+}
+
+// Order returns workspace keys so that dependencies come before their dependents.
+// Ties are broken alphabetically to keep runs deterministic. Given keys, only
+// those workspaces and their transitive dependencies are ordered, so a cycle
+// elsewhere in the graph is not an error; without keys every workspace is.
+func (g WorkspaceGraph) Order(keys ...string) ([]string, error) {
+	nodes := g.keys
+	if len(keys) > 0 {
+		nodes = g.closure(keys)
+	}
+
+	remaining := make(map[string]int, len(nodes))
+	dependents := make(map[string][]string, len(nodes))
+	for _, key := range nodes {
+		remaining[key] = len(g.deps[key])
+		for _, dep := range g.deps[key] {
+			dependents[dep] = append(dependents[dep], key)
+		}
+	}
+
+	ready := make([]string, 0)
+	for _, key := range nodes {
+		if remaining[key] == 0 {
+			ready = append(ready, key)
+		}
+	}
+
+	order := make([]string, 0, len(nodes))
+	for len(ready) > 0 {
+		sort.Strings(ready)
+		key := ready[0]
+		ready = ready[1:]
+		order = append(order, key)
+		for _, dependent := range dependents[key] {
+			remaining[dependent]--
+			if remaining[dependent] == 0 {
+				ready = append(ready, dependent)
+			}
+		}
+	}
+
+	if len(order) != len(nodes) {
+		cyclic := make([]string, 0)
+		for _, key := range nodes {
+			if remaining[key] > 0 {
+				cyclic = append(cyclic, key)
+			}
+		}
+		return nil, fmt.Errorf("%w: %s", ErrDependencyCycle, strings.Join(cyclic, ", "))
+	}
+	return order, nil
+}
+
+// closure returns keys and every workspace they depend on, directly or not,
+// sorted.
+func (g WorkspaceGraph) closure(keys []string) []string {
+	seen := make(map[string]bool, len(keys))
+	pending := append([]string(nil), keys...)
+	for len(pending) > 0 {
+		key := pending[len(pending)-1]
+		pending = pending[:len(pending)-1]
+		if seen[key] {
+			continue
+		}
+		if _, ok := g.deps[key]; !ok {
+			continue
+		}
+		seen[key] = true
+		pending = append(pending, g.deps[key]...)
+	}
+
+	out := make([]string, 0, len(seen))
+	for _, key := range g.keys {
+		if seen[key] {
+			out = append(out, key)
+		}
+	}
+	return out
+}
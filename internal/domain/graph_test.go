@@ -0,0 +1,98 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestWorkspaceGraphOrder(t *testing.T) {
+	graph := NewWorkspaceGraph(map[string]PackageInfo{
+		"web":    {Name: "@acme/web", DependencyVersions: map[string]string{"@acme/ui": "workspace:*", "react": "^19.0.0"}},
+		"ui":     {Name: "@acme/ui", DependencyVersions: map[string]string{"@acme/tokens": "workspace:^"}},
+		"tokens": {Name: "@acme/tokens"},
+		"docs":   {Name: "docs"},
+	})
+
+	got, err := graph.Order()
+	if err != nil {
+		t.Fatalf("Order() error = %v", err)
+	}
+	want := []string{"docs", "tokens", "ui", "web"}
+	if len(got) != len(want) {
+		t.Fatalf("Order() = %#v, want %#v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("Order()[%d] = %q, want %q (%#v)", i, got[i], want[i], got)
+		}
+	}
+
+	if deps := graph.Dependencies("web"); len(deps) != 1 || deps[0] != "ui" {
+		t.Fatalf("Dependencies(web) = %#v", deps)
+	}
+}
+
+func TestWorkspaceGraphCycle(t *testing.T) {
+	graph := NewWorkspaceGraph(map[string]PackageInfo{
+		"a": {Name: "a", DependencyVersions: map[string]string{"b": "workspace:*"}},
+		"b": {Name: "b", DependencyVersions: map[string]string{"a": "workspace:*"}},
+	})
+
+	if _, err := graph.Order(); !errors.Is(err, ErrDependencyCycle) {
+		t.Fatalf("Order() error = %v, want ErrDependencyCycle", err)
+	}
+}
+
+func TestWorkspaceGraphOrderIgnoresUnrelatedCycle(t *testing.T) {
+	graph := NewWorkspaceGraph(map[string]PackageInfo{
+		"a": {Name: "a", DependencyVersions: map[string]string{"b": "workspace:*"}},
+		"b": {Name: "b"},
+		"x": {Name: "x", DependencyVersions: map[string]string{"y": "workspace:*"}},
+		"y": {Name: "y", DependencyVersions: map[string]string{"x": "workspace:*"}},
+	})
+
+	got, err := graph.Order("a")
+	if err != nil {
+		t.Fatalf("Order(a) error = %v", err)
+	}
+	if len(got) != 2 || got[0] != "b" || got[1] != "a" {
+		t.Fatalf("Order(a) = %#v, want [b a]", got)
+	}
+	if _, err := graph.Order("a", "x"); !errors.Is(err, ErrDependencyCycle) {
+		t.Fatalf("Order(a, x) error = %v, want ErrDependencyCycle", err)
+	}
+	if _, err := graph.Order(); !errors.Is(err, ErrDependencyCycle) {
+		t.Fatalf("Order() error = %v, want ErrDependencyCycle", err)
+	}
+}
+
+func TestWorkspaceGraphLinksOnlyLocalSpecs(t *testing.T) {
+	graph := NewWorkspaceGraph(map[string]PackageInfo{
+		"web":    {Name: "@acme/web", Dir: "apps/web", DependencyVersions: map[string]string{"@acme/ui": "^1.0.0", "@acme/tokens": "file:../../packages/tokens"}},
+		"docs":   {Name: "@acme/docs", Dir: "apps/docs", DependencyVersions: map[string]string{"@acme/ui": "link:../web", "@acme/tokens": "portal:../../packages/tokens"}},
+		"ui":     {Name: "@acme/ui", Dir: "packages/ui", DependencyVersions: map[string]string{"@acme/web": "^2.0.0"}},
+		"tokens": {Name: "@acme/tokens", Dir: "packages/tokens"},
+	})
+
+	if deps := graph.Dependencies("web"); len(deps) != 1 || deps[0] != "tokens" {
+		t.Fatalf("Dependencies(web) = %#v, want [tokens]", deps)
+	}
+	if deps := graph.Dependencies("docs"); len(deps) != 1 || deps[0] != "tokens" {
+		t.Fatalf("Dependencies(docs) = %#v, want [tokens]", deps)
+	}
+	if _, err := graph.Order(); err != nil {
+		t.Fatalf("Order() error = %v, want registry-pinned siblings to add no cycle", err)
+	}
+}
+
+func TestMatchWorkspace(t *testing.T) {
+	pkg := PackageInfo{Dir: "packages/ui", WorkspaceKey: "ui", Name: "@acme/ui"}
+	for _, pattern := range []string{"ui", "packages/*", "@acme/*"} {
+		if !MatchWorkspace(pattern, pkg) {
+			t.Fatalf("MatchWorkspace(%q) = false, want true", pattern)
+		}
+	}
+	if MatchWorkspace("apps/*", pkg) {
+		t.Fatal("MatchWorkspace(apps/*) = true, want false")
+	}
+}
@@ -39,6 +39,11 @@ func ParsePackageManager(raw string) (PackageManager, error) {
 	}
 }
 
+// Lockfiles lists the lockfile names written by supported package managers.
+func Lockfiles() []string {
+	return []string{"bun.lockb", "bun.lock", "pnpm-lock.yaml", "yarn.lock", "package-lock.json", "npm-shrinkwrap.json"}
+}
+
 func DetectManager(lockfiles map[string]bool) PackageManager {
 	if lockfiles["bun.lockb"] || lockfiles["bun.lock"] {
 		return ManagerBun
@@ -55,6 +60,120 @@ func DetectManager(lockfiles map[string]bool) PackageManager {
 	return ManagerNPM
 }
 
+// ParsePackageManagerField parses a Corepack "packageManager" value such as
+// "pnpm@9.1.0+sha512.abc" into the manager and its version.
+func ParsePackageManagerField(raw string) (PackageManager, string, error) {
+	value := strings.TrimSpace(raw)
+	if value == "" {
+		return "", "", fmt.Errorf("packageManager field cannot be empty")
+	}
+
+	name, version, _ := strings.Cut(value, "@")
+	version, _, _ = strings.Cut(version, "+")
+	manager, err := ParsePackageManager(name)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid packageManager field %q: %w", raw, err)
+	}
+	return manager, version, nil
+}
+
+// LockfileManagers returns the distinct managers owning the given lockfiles, in
+// DetectManager precedence order.
+func LockfileManagers(lockfiles map[string]bool) []PackageManager {
+	owners := []struct {
+		manager PackageManager
+		files   []string
+	}{
+		{ManagerBun, []string{"bun.lockb", "bun.lock"}},
+		{ManagerPNPM, []string{"pnpm-lock.yaml"}},
+		{ManagerYarn, []string{"yarn.lock"}},
+		{ManagerNPM, []string{"package-lock.json", "npm-shrinkwrap.json"}},
+	}
+
+	managers := make([]PackageManager, 0, len(owners))
+	for _, owner := range owners {
+		for _, file := range owner.files {
+			if lockfiles[file] {
+				managers = append(managers, owner.manager)
+				break
+			}
+		}
+	}
+	return managers
+}
+
+type ManagerSource string
+
+const (
+	SourceOverride            ManagerSource = "override"
+	SourcePackageManagerField ManagerSource = "packageManager"
+	SourceLockfile            ManagerSource = "lockfile"
+	SourceProjectConfig       ManagerSource = "project config"
+	SourceUserConfig          ManagerSource = "user config"
+	SourceFallback            ManagerSource = "fallback"
+)
+
+// ManagerHints holds every signal used to pick a project's package manager.
+type ManagerHints struct {
+	// Override is an explicitly requested manager that beats every other signal.
+	Override            PackageManager
+	PackageManagerField string
+	Lockfiles           map[string]bool
+	ProjectDefault      string
+	UserDefault         string
+}
+
+type ManagerResolution struct {
+	Manager PackageManager
+	Version string
+	Source  ManagerSource
+	// LockfileManagers lists every manager with a lockfile present; more than one
+	// means the lockfiles conflict.
+	LockfileManagers []PackageManager
+}
+
+// ResolveManager picks the package manager from, in order: the explicit override,
+// the packageManager field, the lockfiles, the project default, and the user
+// default, falling back to npm.
+func ResolveManager(hints ManagerHints) (ManagerResolution, error) {
+	resolution := ManagerResolution{LockfileManagers: LockfileManagers(hints.Lockfiles)}
+
+	switch {
+	case hints.Override != "":
+		resolution.Manager = hints.Override
+		resolution.Source = SourceOverride
+	case strings.TrimSpace(hints.PackageManagerField) != "":
+		manager, version, err := ParsePackageManagerField(hints.PackageManagerField)
+		if err != nil {
+			return ManagerResolution{}, err
+		}
+		resolution.Manager = manager
+		resolution.Version = version
+		resolution.Source = SourcePackageManagerField
+	case len(resolution.LockfileManagers) > 0:
+		resolution.Manager = DetectManager(hints.Lockfiles)
+		resolution.Source = SourceLockfile
+	case strings.TrimSpace(hints.ProjectDefault) != "":
+		manager, err := ParsePackageManager(hints.ProjectDefault)
+		if err != nil {
+			return ManagerResolution{}, fmt.Errorf("project defaultPackageManager: %w", err)
+		}
+		resolution.Manager = manager
+		resolution.Source = SourceProjectConfig
+	case strings.TrimSpace(hints.UserDefault) != "":
+		manager, err := ParsePackageManager(hints.UserDefault)
+		if err != nil {
+			return ManagerResolution{}, fmt.Errorf("user defaultPackageManager: %w", err)
+		}
+		resolution.Manager = manager
+		resolution.Source = SourceUserConfig
+	default:
+		resolution.Manager = ManagerNPM
+		resolution.Source = SourceFallback
+	}
+	return resolution, nil
+}
+
 func BuildRunCommand(manager PackageManager, script string, extraArgs []string) ([]string, error) {
 	if script == "" {
 		return nil, fmt.Errorf("script cannot be empty")
@@ -402,3 +402,76 @@ func TestParsePackageManager(t *testing.T) {
 		}
 	}
 }
+
+func TestParsePackageManagerField(t *testing.T) {
+	manager, version, err := ParsePackageManagerField("pnpm@9.1.0+sha512.abcdef")
+	if err != nil {
+		t.Fatalf("ParsePackageManagerField() error = %v", err)
+	}
+	if manager != ManagerPNPM || version != "9.1.0" {
+		t.Fatalf("ParsePackageManagerField() = %s, %q", manager, version)
+	}
+
+	if _, _, err := ParsePackageManagerField("deno@2.0.0"); err == nil {
+		t.Fatal("ParsePackageManagerField(deno) error = nil, want non-nil")
+	}
+}
+
+func TestResolveManagerOrder(t *testing.T) {
+	tests := []struct {
+		name       string
+		hints      ManagerHints
+		want       PackageManager
+		wantSource ManagerSource
+	}{
+		{
+			name:       "packageManager field wins over lockfiles",
+			hints:      ManagerHints{PackageManagerField: "yarn@4.1.0", Lockfiles: map[string]bool{"pnpm-lock.yaml": true}, UserDefault: "bun"},
+			want:       ManagerYarn,
+			wantSource: SourcePackageManagerField,
+		},
+		{
+			name:       "lockfiles win over defaults",
+			hints:      ManagerHints{Lockfiles: map[string]bool{"pnpm-lock.yaml": true}, ProjectDefault: "bun", UserDefault: "yarn"},
+			want:       ManagerPNPM,
+			wantSource: SourceLockfile,
+		},
+		{
+			name:       "project default wins over user default",
+			hints:      ManagerHints{ProjectDefault: "bun", UserDefault: "yarn"},
+			want:       ManagerBun,
+			wantSource: SourceProjectConfig,
+		},
+		{
+			name:       "user default",
+			hints:      ManagerHints{UserDefault: "yarn"},
+			want:       ManagerYarn,
+			wantSource: SourceUserConfig,
+		},
+		{
+			name:       "fallback npm",
+			hints:      ManagerHints{},
+			want:       ManagerNPM,
+			wantSource: SourceFallback,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ResolveManager(tc.hints)
+			if err != nil {
+				t.Fatalf("ResolveManager() error = %v", err)
+			}
+			if got.Manager != tc.want || got.Source != tc.wantSource {
+				t.Fatalf("ResolveManager() = %s from %s, want %s from %s", got.Manager, got.Source, tc.want, tc.wantSource)
+			}
+		})
+	}
+}
+
+func TestLockfileManagers(t *testing.T) {
+	got := LockfileManagers(map[string]bool{"yarn.lock": true, "bun.lock": true, "bun.lockb": true})
+	if len(got) != 2 || got[0] != ManagerBun || got[1] != ManagerYarn {
+		t.Fatalf("LockfileManagers() = %#v", got)
+	}
+}
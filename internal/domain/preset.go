@@ -2,9 +2,17 @@ package domain
 
 import (
 	"fmt"
+	"regexp"
+	"slices"
 	"strings"
 )
 
+var presetNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+// reservedPresetNames are the ordo preset subcommands, which cobra resolves
+// before a preset of the same name.
+var reservedPresetNames = []string{"add", "apply", "create", "delete", "list", "remove", "save"}
+
 type PresetBucket string
 
 const (
@@ -39,6 +47,26 @@ func ParsePresetBucket(raw string) (PresetBucket, error) {
 	}
 }
 
+func ValidatePresetName(raw string) error {
+	name := strings.TrimSpace(raw)
+	if name == "" {
+		return fmt.Errorf("preset name cannot be empty")
+	}
+	if !presetNamePattern.MatchString(name) {
+		return fmt.Errorf("invalid preset name %q (allowed: letters, digits, '.', '_' and '-')", raw)
+	}
+	if slices.Contains(reservedPresetNames, name) {
+		return fmt.Errorf("preset name %q is reserved for the ordo preset %s subcommand", name, name)
+	}
+	return nil
+}
+
+// ReservedPresetNames returns the names ValidatePresetName rejects because an
+// ordo preset subcommand uses them.
+func ReservedPresetNames() []string {
+	return slices.Clone(reservedPresetNames)
+}
+
 func BucketInstallOptions(bucket PresetBucket) InstallOptions {
 	switch bucket {
 	case BucketDevDependencies:
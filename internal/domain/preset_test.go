@@ -56,3 +56,16 @@ func TestBucketInstallOptions(t *testing.T) {
 		})
 	}
 }
+
+func TestValidatePresetName(t *testing.T) {
+	for _, name := range []string{"prettier", "eslint-react", "Vite.5"} {
+		if err := ValidatePresetName(name); err != nil {
+			t.Fatalf("ValidatePresetName(%q) error = %v", name, err)
+		}
+	}
+	for _, name := range []string{"", " ", "-lint", "my preset", "a/b", "list", "apply", " save "} {
+		if err := ValidatePresetName(name); err == nil {
+			t.Fatalf("ValidatePresetName(%q) error = nil, want non-nil", name)
+		}
+	}
+}
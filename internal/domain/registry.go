@@ -0,0 +1,14 @@
+package domain
+
+import "errors"
+
+// ErrPackageNotPublished reports a package the registry does not know.
+var ErrPackageNotPublished = errors.New("package not published")
+
+// PackageMetadata is the registry view of a package: its dist-tags and every
+// published version.
+type PackageMetadata struct {
+	Name     string
+	DistTags map[string]string
+	Versions []string
+}
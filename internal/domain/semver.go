@@ -0,0 +1,457 @@
+package domain
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Version is a parsed semantic version. Build metadata is dropped.
+type Version struct {
+	Major      int
+	Minor      int
+	Patch      int
+	Prerelease string
+}
+
+func ParseVersion(raw string) (Version, error) {
+	value := strings.TrimPrefix(strings.TrimSpace(raw), "v")
+	parts, n, pre, err := parsePartialVersion(value)
+	if err != nil {
+		return Version{}, err
+	}
+	if n != 3 {
+		return Version{}, fmt.Errorf("invalid version: %q", raw)
+	}
+	return Version{Major: parts[0], Minor: parts[1], Patch: parts[2], Prerelease: pre}, nil
+}
+
+func (v Version) String() string {
+	out := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+	if v.Prerelease != "" {
+		out += "-" + v.Prerelease
+	}
+	return out
+}
+
+// Compare returns -1, 0 or 1 following semver precedence rules.
+func (v Version) Compare(other Version) int {
+	if c := compareInt(v.Major, other.Major); c != 0 {
+		return c
+	}
+	if c := compareInt(v.Minor, other.Minor); c != 0 {
+		return c
+	}
+	if c := compareInt(v.Patch, other.Patch); c != 0 {
+		return c
+	}
+	return comparePrerelease(v.Prerelease, other.Prerelease)
+}
+
+func (v Version) sameTuple(other Version) bool {
+	return v.Major == other.Major && v.Minor == other.Minor && v.Patch == other.Patch
+}
+
+// Range is an npm-style version range such as "^1.2.3", "~1.2", ">=1 <3" or
+// "1.x || 2.1.0 - 2.4".
+type Range struct {
+	sets [][]comparator
+}
+
+type comparator struct {
+	op      string
+	version Version
+}
+
+func ParseRange(raw string) (Range, error) {
+	var out Range
+	for _, alternative := range strings.Split(raw, "||") {
+		set, err := parseComparatorSet(alternative)
+		if err != nil {
+			return Range{}, fmt.Errorf("invalid version range %q: %w", raw, err)
+		}
+		out.sets = append(out.sets, set)
+	}
+	return out, nil
+}
+
+// Satisfies reports whether v is inside the range. Prereleases only match
+// when a comparator in the same set names a prerelease of the same version.
+func (r Range) Satisfies(v Version) bool {
+	for _, set := range r.sets {
+		if setSatisfies(set, v) {
+			return true
+		}
+	}
+	return false
+}
+
+// MaxSatisfying returns the highest version inside the range.
+func (r Range) MaxSatisfying(versions []Version) (Version, bool) {
+	var best Version
+	found := false
+	for _, v := range versions {
+		if !r.Satisfies(v) {
+			continue
+		}
+		if !found || v.Compare(best) > 0 {
+			best = v
+			found = true
+		}
+	}
+	return best, found
+}
+
+// SimpleRange splits a range made of one operator and one full version, such
+// as "^1.2.3", "~1.2.3", ">=1.2.3" or "1.2.3". Anything else reports false.
+func SimpleRange(raw string) (string, Version, bool) {
+	value := strings.TrimSpace(raw)
+	op := ""
+	for _, candidate := range []string{">=", "^", "~", "="} {
+		if strings.HasPrefix(value, candidate) {
+			op = candidate
+			break
+		}
+	}
+	v, err := ParseVersion(strings.TrimSpace(strings.TrimPrefix(value, op)))
+	if err != nil || strings.HasPrefix(strings.TrimPrefix(value, op), "v") {
+		return "", Version{}, false
+	}
+	return op, v, true
+}
+
+// HighestRange picks the simple range with the highest minimum version, such
+// as "^18.3.1" from "^18.2.0" and "^18.3.1". Compound ranges are ignored.
+func HighestRange(ranges []string) (string, bool) {
+	best := ""
+	var bestVersion Version
+	for _, raw := range ranges {
+		_, v, ok := SimpleRange(raw)
+		if !ok {
+			continue
+		}
+		if best == "" || v.Compare(bestVersion) > 0 {
+			best = strings.TrimSpace(raw)
+			bestVersion = v
+		}
+	}
+	return best, best != ""
+}
+
+type UpgradeLevel string
+
+const (
+	UpgradeMajor UpgradeLevel = "major"
+	UpgradeMinor UpgradeLevel = "minor"
+	UpgradePatch UpgradeLevel = "patch"
+)
+
+// UpgradeTarget picks the highest stable version above current that does not
+// pass latest and stays within level: the same major for minor upgrades, the
+// same major and minor for patch upgrades.
+func UpgradeTarget(current Version, latest Version, versions []Version, level UpgradeLevel) (Version, bool) {
+	var best Version
+	found := false
+	for _, v := range versions {
+		if v.Prerelease != "" || v.Compare(current) <= 0 || v.Compare(latest) > 0 {
+			continue
+		}
+		if level != UpgradeMajor && v.Major != current.Major {
+			continue
+		}
+		if level == UpgradePatch && v.Minor != current.Minor {
+			continue
+		}
+		if !found || v.Compare(best) > 0 {
+			best = v
+			found = true
+		}
+	}
+	return best, found
+}
+
+func setSatisfies(set []comparator, v Version) bool {
+	for _, c := range set {
+		if !c.matches(v) {
+			return false
+		}
+	}
+	if v.Prerelease == "" {
+		return true
+	}
+	for _, c := range set {
+		if c.version.Prerelease != "" && c.version.sameTuple(v) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c comparator) matches(v Version) bool {
+	cmp := v.Compare(c.version)
+	switch c.op {
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	case ">":
+		return cmp > 0
+	case ">=":
+		return cmp >= 0
+	default:
+		return cmp == 0
+	}
+}
+
+func parseComparatorSet(raw string) ([]comparator, error) {
+	fields := strings.Fields(raw)
+	if len(fields) == 0 {
+		return []comparator{{op: ">=", version: Version{}}}, nil
+	}
+	if len(fields) == 3 && fields[1] == "-" {
+		return parseHyphenRange(fields[0], fields[2])
+	}
+
+	// Allow a space between an operator and its version, e.g. ">= 1.2.3".
+	tokens := make([]string, 0, len(fields))
+	for i := 0; i < len(fields); i++ {
+		field := fields[i]
+		if isRangeOperator(field) && i+1 < len(fields) {
+			field += fields[i+1]
+			i++
+		}
+		tokens = append(tokens, field)
+	}
+
+	set := []comparator{}
+	for _, token := range tokens {
+		items, err := parseComparator(token)
+		if err != nil {
+			return nil, err
+		}
+		set = append(set, items...)
+	}
+	return set, nil
+}
+
+func parseHyphenRange(lowRaw, highRaw string) ([]comparator, error) {
+	low, lowN, lowPre, err := parsePartialVersion(lowRaw)
+	if err != nil {
+		return nil, err
+	}
+	high, highN, highPre, err := parsePartialVersion(highRaw)
+	if err != nil {
+		return nil, err
+	}
+
+	set := []comparator{{op: ">=", version: partialVersion(low, lowN, lowPre)}}
+	switch highN {
+	case 0:
+	case 3:
+		set = append(set, comparator{op: "<=", version: partialVersion(high, highN, highPre)})
+	default:
+		set = append(set, comparator{op: "<", version: partialUpperBound(high, highN)})
+	}
+	return set, nil
+}
+
+func parseComparator(token string) ([]comparator, error) {
+	op := ""
+	for _, candidate := range []string{"<=", ">=", "<", ">", "=", "^", "~>", "~"} {
+		if strings.HasPrefix(token, candidate) {
+			op = candidate
+			break
+		}
+	}
+	value := strings.TrimPrefix(strings.TrimPrefix(token, op), "v")
+	parts, n, pre, err := parsePartialVersion(value)
+	if err != nil {
+		return nil, err
+	}
+	base := partialVersion(parts, n, pre)
+
+	switch op {
+	case "^":
+		return caretRange(parts, n, base), nil
+	case "~", "~>":
+		if n == 0 {
+			return []comparator{{op: ">=", version: Version{}}}, nil
+		}
+		upper := partialUpperBound(parts, min(n, 2))
+		return []comparator{{op: ">=", version: base}, {op: "<", version: upper}}, nil
+	case ">":
+		if n == 0 {
+			return []comparator{{op: "<", version: Version{Prerelease: "0"}}}, nil
+		}
+		if n < 3 {
+			return []comparator{{op: ">=", version: partialUpperBound(parts, n)}}, nil
+		}
+		return []comparator{{op: ">", version: base}}, nil
+	case ">=":
+		return []comparator{{op: ">=", version: base}}, nil
+	case "<":
+		if n < 3 {
+			return []comparator{{op: "<", version: withZeroPrerelease(base)}}, nil
+		}
+		return []comparator{{op: "<", version: base}}, nil
+	case "<=":
+		if n == 0 {
+			return []comparator{{op: ">=", version: Version{}}}, nil
+		}
+		if n < 3 {
+			return []comparator{{op: "<", version: partialUpperBound(parts, n)}}, nil
+		}
+		return []comparator{{op: "<=", version: base}}, nil
+	default:
+		if n == 0 {
+			return []comparator{{op: ">=", version: Version{}}}, nil
+		}
+		if n < 3 {
+			return []comparator{{op: ">=", version: base}, {op: "<", version: partialUpperBound(parts, n)}}, nil
+		}
+		return []comparator{{op: "=", version: base}}, nil
+	}
+}
+
+func caretRange(parts [3]int, n int, base Version) []comparator {
+	if n == 0 {
+		return []comparator{{op: ">=", version: Version{}}}
+	}
+	var upper Version
+	switch {
+	case parts[0] != 0 || n == 1:
+		upper = Version{Major: parts[0] + 1, Prerelease: "0"}
+	case parts[1] != 0 || n == 2:
+		upper = Version{Minor: parts[1] + 1, Prerelease: "0"}
+	default:
+		upper = Version{Patch: parts[2] + 1, Prerelease: "0"}
+	}
+	return []comparator{{op: ">=", version: base}, {op: "<", version: upper}}
+}
+
+// parsePartialVersion parses "1", "1.2", "1.x", "*" and full versions. It
+// returns how many leading components were given.
+func parsePartialVersion(raw string) ([3]int, int, string, error) {
+	var parts [3]int
+	value := strings.TrimSpace(raw)
+	if idx := strings.Index(value, "+"); idx >= 0 {
+		value = value[:idx]
+	}
+	pre := ""
+	if idx := strings.Index(value, "-"); idx >= 0 {
+		pre = value[idx+1:]
+		value = value[:idx]
+		if pre == "" {
+			return parts, 0, "", fmt.Errorf("invalid version: %q", raw)
+		}
+	}
+	if value == "" {
+		return parts, 0, "", fmt.Errorf("invalid version: %q", raw)
+	}
+
+	fields := strings.Split(value, ".")
+	if len(fields) > 3 {
+		return parts, 0, "", fmt.Errorf("invalid version: %q", raw)
+	}
+	n := 0
+	for i, field := range fields {
+		if field == "x" || field == "X" || field == "*" {
+			break
+		}
+		number, err := strconv.Atoi(field)
+		if err != nil || number < 0 {
+			return parts, 0, "", fmt.Errorf("invalid version: %q", raw)
+		}
+		parts[i] = number
+		n = i + 1
+	}
+	if n < 3 {
+		pre = ""
+	}
+	return parts, n, pre, nil
+}
+
+func partialVersion(parts [3]int, n int, pre string) Version {
+	v := Version{Prerelease: pre}
+	if n > 0 {
+		v.Major = parts[0]
+	}
+	if n > 1 {
+		v.Minor = parts[1]
+	}
+	if n > 2 {
+		v.Patch = parts[2]
+	}
+	return v
+}
+
+// partialUpperBound returns the first prerelease past the given components,
+// so "1" yields 2.0.0-0 and "1.2" yields 1.3.0-0.
+func partialUpperBound(parts [3]int, n int) Version {
+	switch n {
+	case 1:
+		return Version{Major: parts[0] + 1, Prerelease: "0"}
+	case 2:
+		return Version{Major: parts[0], Minor: parts[1] + 1, Prerelease: "0"}
+	default:
+		return Version{Major: parts[0], Minor: parts[1], Patch: parts[2] + 1, Prerelease: "0"}
+	}
+}
+
+func withZeroPrerelease(v Version) Version {
+	v.Prerelease = "0"
+	return v
+}
+
+func isRangeOperator(value string) bool {
+	switch value {
+	case "<", "<=", ">", ">=", "=", "^", "~", "~>":
+		return true
+	default:
+		return false
+	}
+}
+
+func compareInt(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func comparePrerelease(a, b string) int {
+	switch {
+	case a == b:
+		return 0
+	case a == "":
+		return 1
+	case b == "":
+		return -1
+	}
+
+	left := strings.Split(a, ".")
+	right := strings.Split(b, ".")
+	for i := 0; i < len(left) && i < len(right); i++ {
+		ln, lErr := strconv.Atoi(left[i])
+		rn, rErr := strconv.Atoi(right[i])
+		var c int
+		switch {
+		case lErr == nil && rErr == nil:
+			c = compareInt(ln, rn)
+		case lErr == nil:
+			c = -1
+		case rErr == nil:
+			c = 1
+		default:
+			c = strings.Compare(left[i], right[i])
+		}
+		if c != 0 {
+			return c
+		}
+	}
+	return compareInt(len(left), len(right))
+}
@@ -0,0 +1,167 @@
+package domain
+
+import "testing"
+
+func mustVersion(t *testing.T, raw string) Version {
+	t.Helper()
+	v, err := ParseVersion(raw)
+	if err != nil {
+		t.Fatalf("ParseVersion(%q) error = %v", raw, err)
+	}
+	return v
+}
+
+func TestParseVersion(t *testing.T) {
+	v := mustVersion(t, "v1.2.3-beta.1+build.5")
+	if v.Major != 1 || v.Minor != 2 || v.Patch != 3 || v.Prerelease != "beta.1" {
+		t.Fatalf("unexpected version: %#v", v)
+	}
+	if got := v.String(); got != "1.2.3-beta.1" {
+		t.Fatalf("String() = %q", got)
+	}
+	for _, raw := range []string{"", "1.2", "1.x.3", "a.b.c", "1.2.3-"} {
+		if _, err := ParseVersion(raw); err == nil {
+			t.Fatalf("ParseVersion(%q) error = nil, want non-nil", raw)
+		}
+	}
+}
+
+func TestVersionCompare(t *testing.T) {
+	ordered := []string{"1.0.0-alpha", "1.0.0-alpha.1", "1.0.0-alpha.beta", "1.0.0-beta", "1.0.0-beta.2", "1.0.0-beta.11", "1.0.0-rc.1", "1.0.0", "1.0.1", "1.1.0", "2.0.0"}
+	for i := 1; i < len(ordered); i++ {
+		a, b := mustVersion(t, ordered[i-1]), mustVersion(t, ordered[i])
+		if a.Compare(b) >= 0 || b.Compare(a) <= 0 {
+			t.Fatalf("expected %s < %s", ordered[i-1], ordered[i])
+		}
+	}
+}
+
+func TestRangeSatisfies(t *testing.T) {
+	tests := []struct {
+		rng     string
+		version string
+		want    bool
+	}{
+		{"^1.2.3", "1.9.0", true},
+		{"^1.2.3", "2.0.0", false},
+		{"^1.2.3", "1.2.2", false},
+		{"^0.2.3", "0.2.9", true},
+		{"^0.2.3", "0.3.0", false},
+		{"^0.0.3", "0.0.4", false},
+		{"~1.2.3", "1.2.9", true},
+		{"~1.2.3", "1.3.0", false},
+		{"~1", "1.9.9", true},
+		{"1.x", "1.4.0", true},
+		{"1.x", "2.0.0", false},
+		{"*", "3.1.4", true},
+		{"", "3.1.4", true},
+		{">=1.2 <2", "1.5.0", true},
+		{">= 1.2.0 < 2.0.0", "2.0.0", false},
+		{">1.2", "1.2.9", false},
+		{">1.2", "1.3.0", true},
+		{"<=1.2", "1.2.9", true},
+		{"1.2.3 - 2.3", "2.3.9", true},
+		{"1.2.3 - 2.3", "2.4.0", false},
+		{"^1.0.0 || ^2.0.0", "2.1.0", true},
+		{"1.2.3", "1.2.3", true},
+		{"=1.2.3", "1.2.4", false},
+		{"^1.2.3", "1.3.0-beta.1", false},
+		{"^1.2.3-beta.1", "1.2.3-beta.2", true},
+		{"^1.2.3-beta.1", "1.2.4-beta.1", false},
+	}
+
+	for _, tc := range tests {
+		r, err := ParseRange(tc.rng)
+		if err != nil {
+			t.Fatalf("ParseRange(%q) error = %v", tc.rng, err)
+		}
+		if got := r.Satisfies(mustVersion(t, tc.version)); got != tc.want {
+			t.Fatalf("ParseRange(%q).Satisfies(%s) = %v, want %v", tc.rng, tc.version, got, tc.want)
+		}
+	}
+}
+
+func TestParseRangeRejectsInvalid(t *testing.T) {
+	for _, raw := range []string{"workspace:*", "npm:react@18", "^a.b"} {
+		if _, err := ParseRange(raw); err == nil {
+			t.Fatalf("ParseRange(%q) error = nil, want non-nil", raw)
+		}
+	}
+}
+
+func TestRangeMaxSatisfying(t *testing.T) {
+	versions := []Version{mustVersion(t, "18.2.0"), mustVersion(t, "18.3.1"), mustVersion(t, "19.0.0"), mustVersion(t, "19.1.0-rc.0")}
+	r, err := ParseRange("^18.2.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, ok := r.MaxSatisfying(versions)
+	if !ok || got.String() != "18.3.1" {
+		t.Fatalf("MaxSatisfying() = %s, %v", got, ok)
+	}
+}
+
+func TestSimpleRange(t *testing.T) {
+	tests := []struct {
+		in      string
+		wantOp  string
+		wantVer string
+		wantOK  bool
+	}{
+		{in: "^1.2.3", wantOp: "^", wantVer: "1.2.3", wantOK: true},
+		{in: "~1.2.3", wantOp: "~", wantVer: "1.2.3", wantOK: true},
+		{in: ">=1.2.3", wantOp: ">=", wantVer: "1.2.3", wantOK: true},
+		{in: "1.2.3", wantOp: "", wantVer: "1.2.3", wantOK: true},
+		{in: "^1.2", wantOK: false},
+		{in: ">=1.2.3 <2", wantOK: false},
+		{in: "catalog:", wantOK: false},
+	}
+
+	for _, tc := range tests {
+		op, v, ok := SimpleRange(tc.in)
+		if ok != tc.wantOK {
+			t.Fatalf("SimpleRange(%q) ok = %v, want %v", tc.in, ok, tc.wantOK)
+		}
+		if ok && (op != tc.wantOp || v.String() != tc.wantVer) {
+			t.Fatalf("SimpleRange(%q) = %q, %s", tc.in, op, v)
+		}
+	}
+}
+
+func TestUpgradeTarget(t *testing.T) {
+	versions := []Version{
+		mustVersion(t, "1.2.3"), mustVersion(t, "1.2.5"), mustVersion(t, "1.4.0"),
+		mustVersion(t, "2.0.0"), mustVersion(t, "2.1.0"), mustVersion(t, "3.0.0-beta.1"),
+	}
+	current := mustVersion(t, "1.2.3")
+	latest := mustVersion(t, "2.1.0")
+
+	tests := []struct {
+		level UpgradeLevel
+		want  string
+	}{
+		{UpgradeMajor, "2.1.0"},
+		{UpgradeMinor, "1.4.0"},
+		{UpgradePatch, "1.2.5"},
+	}
+	for _, tc := range tests {
+		got, ok := UpgradeTarget(current, latest, versions, tc.level)
+		if !ok || got.String() != tc.want {
+			t.Fatalf("UpgradeTarget(%s) = %s, %v; want %s", tc.level, got, ok, tc.want)
+		}
+	}
+
+	if _, ok := UpgradeTarget(latest, latest, versions, UpgradeMajor); ok {
+		t.Fatal("UpgradeTarget(latest) ok = true, want false")
+	}
+}
+
+func TestHighestRange(t *testing.T) {
+	got, ok := HighestRange([]string{"^18.2.0", ">=17 <19", "~18.3.1", "18.1.0"})
+	if !ok || got != "~18.3.1" {
+		t.Fatalf("HighestRange() = %q, %v", got, ok)
+	}
+	if _, ok := HighestRange([]string{">=17 <19", "1.x"}); ok {
+		t.Fatal("HighestRange(compound only) ok = true, want false")
+	}
+}
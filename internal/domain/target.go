@@ -8,34 +8,64 @@ import (
 
 var ErrInvalidTarget = errors.New("invalid target")
 
+// RootWorkspace addresses the project root explicitly, e.g. "./build", even when
+// bare targets default to the current workspace.
+const RootWorkspace = "."
+
+// TargetSeparator splits a workspace reference from a script or package name.
+const TargetSeparator = "#"
+
 type Target struct {
 	Workspace string
 	Name      string
 }
 
+// ParseTarget parses "<name>", "<workspace>/<name>", or "<workspace>#<name>".
+// The "#" form accepts any workspace reference, including scoped package names
+// such as "@acme/web#build". Scoped names also work in the slash form when the
+// split is unambiguous: "@types/node", "ui/@types/node", and "@acme/web/build".
 func ParseTarget(raw string) (Target, error) {
 	trimmed := strings.TrimSpace(raw)
 	if trimmed == "" {
 		return Target{}, fmt.Errorf("%w: target cannot be empty", ErrInvalidTarget)
 	}
 
-	parts := strings.Split(trimmed, "/")
-	switch len(parts) {
-	case 1:
-		if parts[0] == "" {
-			return Target{}, fmt.Errorf("%w: target cannot be empty", ErrInvalidTarget)
+	if workspace, name, ok := strings.Cut(trimmed, TargetSeparator); ok {
+		if workspace == "" || name == "" {
+			return Target{}, fmt.Errorf("%w: workspace#name cannot contain empty segments", ErrInvalidTarget)
 		}
-		return Target{Name: parts[0]}, nil
-	case 2:
-		if parts[0] == "" || parts[1] == "" {
+		return Target{Workspace: workspace, Name: name}, nil
+	}
+
+	parts := strings.Split(trimmed, "/")
+	for _, part := range parts {
+		if part == "" {
 			return Target{}, fmt.Errorf("%w: workspace/name cannot contain empty segments", ErrInvalidTarget)
 		}
+	}
+
+	switch {
+	case len(parts) == 1:
+		return Target{Name: parts[0]}, nil
+	case len(parts) == 2 && isScope(parts[0]):
+		return Target{Name: trimmed}, nil
+	case len(parts) == 2:
 		return Target{Workspace: parts[0], Name: parts[1]}, nil
+	case len(parts) == 3 && isScope(parts[1]):
+		return Target{Workspace: parts[0], Name: parts[1] + "/" + parts[2]}, nil
+	case len(parts) == 3 && isScope(parts[0]):
+		return Target{Workspace: parts[0] + "/" + parts[1], Name: parts[2]}, nil
 	default:
-		return Target{}, fmt.Errorf("%w: expected <name> or <workspace>/<name>", ErrInvalidTarget)
+		return Target{}, fmt.Errorf("%w: expected <name>, <workspace>/<name>, or <workspace>#<name>", ErrInvalidTarget)
 	}
 }
 
+func isScope(segment string) bool {
+	return strings.HasPrefix(segment, "@")
+}
+
+// IsRoot reports whether the target names no workspace. Bare targets resolve to
+// the current workspace, falling back to the root.
 func (t Target) IsRoot() bool {
 	return t.Workspace == ""
 }
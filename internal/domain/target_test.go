@@ -16,6 +16,13 @@ func TestParseTarget(t *testing.T) {
 		{name: "too many segments", in: "a/b/c", wantErr: true},
 		{name: "missing workspace", in: "/build", wantErr: true},
 		{name: "missing name", in: "ui/", wantErr: true},
+		{name: "scoped package", in: "@types/node", target: "@types/node"},
+		{name: "workspace scoped package", in: "ui/@types/node", workspace: "ui", target: "@types/node"},
+		{name: "scoped workspace", in: "@acme/web/build", workspace: "@acme/web", target: "build"},
+		{name: "separator", in: "@acme/web#build:prod", workspace: "@acme/web", target: "build:prod"},
+		{name: "separator scoped package", in: "web#@types/node", workspace: "web", target: "@types/node"},
+		{name: "separator missing workspace", in: "#build", wantErr: true},
+		{name: "separator missing name", in: "web#", wantErr: true},
 	}
 
 	for _, tc := range tests {
@@ -1,14 +1,23 @@
 package domain
 
-import "path/filepath"
+import (
+	"path"
+	"path/filepath"
+)
 
 type PackageInfo struct {
 	Dir                string
+	Name               string
 	WorkspaceKey       string
 	Scripts            map[string]string
 	Dependencies       map[string]struct{}
 	DependencyVersions map[string]string
-	Lockfiles          map[string]bool
+	// DependencyBuckets keeps each manifest dependency section apart;
+	// DependencyVersions merges them.
+	DependencyBuckets map[PresetBucket]map[string]string
+	Lockfiles         map[string]bool
+	// PackageManager is the raw Corepack "packageManager" field, if any.
+	PackageManager string
 }
 
 func WorkspaceKeyFromDir(dir string) string {
@@ -17,3 +26,17 @@ func WorkspaceKeyFromDir(dir string) string {
 	}
 	return filepath.Base(dir)
 }
+
+// MatchWorkspace reports whether a glob pattern selects the workspace by key,
+// directory, or package name.
+func MatchWorkspace(pattern string, pkg PackageInfo) bool {
+	for _, candidate := range []string{pkg.WorkspaceKey, filepath.ToSlash(pkg.Dir), pkg.Name} {
+		if candidate == "" {
+			continue
+		}
+		if ok, err := path.Match(pattern, candidate); err == nil && ok {
+			return true
+		}
+	}
+	return false
+}
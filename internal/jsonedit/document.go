@@ -0,0 +1,455 @@
+// Package jsonedit edits JSON documents in place. Untouched parts of the text
+// keep their key order, indentation, line endings, and trailing newline, so a
+// rewrite only changes the lines it means to.
+package jsonedit
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Document is a JSON object being edited.
+type Document struct {
+	src     []byte
+	indent  string
+	newline string
+	colon   string
+}
+
+type object struct {
+	start   int
+	end     int
+	members []member
+}
+
+type member struct {
+	key        string
+	keyStart   int
+	keyEnd     int
+	valueStart int
+	valueEnd   int
+}
+
+// Parse reads src as a JSON object. Empty input starts a new document.
+func Parse(src []byte) (*Document, error) {
+	if len(bytes.TrimSpace(src)) == 0 {
+		src = []byte("{}\n")
+	}
+	if !json.Valid(src) {
+		return nil, fmt.Errorf("invalid JSON")
+	}
+
+	d := &Document{src: append([]byte(nil), src...)}
+	root, err := d.root()
+	if err != nil {
+		return nil, err
+	}
+	d.detectStyle(root)
+	return d, nil
+}
+
+// Bytes returns the edited document.
+func (d *Document) Bytes() []byte {
+	return append([]byte(nil), d.src...)
+}
+
+// Set stores value at path, creating missing parent objects. Existing keys are
+// replaced where they stand; new keys go after the last member of their object.
+func (d *Document) Set(path []string, value any) error {
+	if len(path) == 0 {
+		return fmt.Errorf("empty path")
+	}
+
+	obj, err := d.root()
+	if err != nil {
+		return err
+	}
+	for i, key := range path {
+		m, ok := obj.member(key)
+		if !ok {
+			return d.insert(obj, key, nestedValue(path[i+1:], value))
+		}
+		if i == len(path)-1 || d.src[m.valueStart] != '{' {
+			if i < len(path)-1 {
+				value = nestedValue(path[i+1:], value)
+			}
+			encoded, err := d.encode(value, d.memberPrefix(m))
+			if err != nil {
+				return err
+			}
+			d.splice(m.valueStart, m.valueEnd, encoded)
+			return nil
+		}
+		obj, err = d.scanObject(m.valueStart)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Delete removes the key at path. Missing keys are ignored.
+func (d *Document) Delete(path []string) error {
+	if len(path) == 0 {
+		return fmt.Errorf("empty path")
+	}
+
+	obj, err := d.root()
+	if err != nil {
+		return err
+	}
+	for _, key := range path[:len(path)-1] {
+		m, ok := obj.member(key)
+		if !ok || d.src[m.valueStart] != '{' {
+			return nil
+		}
+		obj, err = d.scanObject(m.valueStart)
+		if err != nil {
+			return err
+		}
+	}
+
+	key := path[len(path)-1]
+	for i, m := range obj.members {
+		if m.key != key {
+			continue
+		}
+		switch {
+		case len(obj.members) == 1:
+			d.splice(obj.start+1, obj.end-1, "")
+		case i > 0:
+			d.splice(obj.members[i-1].valueEnd, m.valueEnd, "")
+		default:
+			d.splice(m.keyStart, obj.members[1].keyStart, "")
+		}
+		return nil
+	}
+	return nil
+}
+
+// Rename changes the key at path to key, keeping its value and position.
+// Missing keys are ignored.
+func (d *Document) Rename(path []string, key string) error {
+	if len(path) == 0 {
+		return fmt.Errorf("empty path")
+	}
+
+	obj, err := d.root()
+	if err != nil {
+		return err
+	}
+	for _, name := range path[:len(path)-1] {
+		m, ok := obj.member(name)
+		if !ok || d.src[m.valueStart] != '{' {
+			return nil
+		}
+		obj, err = d.scanObject(m.valueStart)
+		if err != nil {
+			return err
+		}
+	}
+
+	m, ok := obj.member(path[len(path)-1])
+	if !ok {
+		return nil
+	}
+	if _, exists := obj.member(key); exists {
+		return fmt.Errorf("key %q already exists", key)
+	}
+	quoted, err := d.encode(key, "")
+	if err != nil {
+		return err
+	}
+	d.splice(m.keyStart, m.keyEnd, quoted)
+	return nil
+}
+
+func (d *Document) insert(obj object, key string, value any) error {
+	quoted, err := d.encode(key, "")
+	if err != nil {
+		return err
+	}
+
+	if len(obj.members) == 0 {
+		if d.indent == "" {
+			encoded, err := d.encode(value, "")
+			if err != nil {
+				return err
+			}
+			d.splice(obj.start+1, obj.end-1, quoted+d.colon+encoded)
+			return nil
+		}
+		base := d.lineIndent(obj.start)
+		prefix := base + d.indent
+		encoded, err := d.encode(value, prefix)
+		if err != nil {
+			return err
+		}
+		d.splice(obj.start+1, obj.end-1, d.newline+prefix+quoted+d.colon+encoded+d.newline+base)
+		return nil
+	}
+
+	last := obj.members[len(obj.members)-1]
+	if prefix, ok := d.ownLine(last.keyStart); ok {
+		encoded, err := d.encode(value, prefix)
+		if err != nil {
+			return err
+		}
+		d.splice(last.valueEnd, last.valueEnd, ","+d.newline+prefix+quoted+d.colon+encoded)
+		return nil
+	}
+
+	separator := ", "
+	if d.indent == "" {
+		separator = ","
+	}
+	if len(obj.members) > 1 {
+		separator = string(d.src[obj.members[len(obj.members)-2].valueEnd:last.keyStart])
+	}
+	encoded, err := d.encode(value, "")
+	if err != nil {
+		return err
+	}
+	d.splice(last.valueEnd, last.valueEnd, separator+quoted+d.colon+encoded)
+	return nil
+}
+
+// encode renders value in the document's style, continuing lines with prefix.
+func (d *Document) encode(value any, prefix string) (string, error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if d.indent != "" {
+		encoder.SetIndent(prefix, d.indent)
+	}
+	if err := encoder.Encode(value); err != nil {
+		return "", err
+	}
+	out := strings.TrimSuffix(buf.String(), "\n")
+	if d.newline != "\n" {
+		out = strings.ReplaceAll(out, "\n", d.newline)
+	}
+	return out, nil
+}
+
+// memberPrefix is the indentation that continuation lines of m's value use.
+func (d *Document) memberPrefix(m member) string {
+	if prefix, ok := d.ownLine(m.keyStart); ok {
+		return prefix
+	}
+	return d.lineIndent(m.keyStart)
+}
+
+// ownLine reports the indentation before pos when nothing else precedes it on
+// its line.
+func (d *Document) ownLine(pos int) (string, bool) {
+	start := bytes.LastIndexByte(d.src[:pos], '\n') + 1
+	segment := d.src[start:pos]
+	if len(bytes.TrimLeft(segment, " \t")) != 0 {
+		return "", false
+	}
+	return string(segment), true
+}
+
+// lineIndent returns the leading whitespace of the line containing pos.
+func (d *Document) lineIndent(pos int) string {
+	start := bytes.LastIndexByte(d.src[:pos], '\n') + 1
+	end := start
+	for end < len(d.src) && (d.src[end] == ' ' || d.src[end] == '\t') {
+		end++
+	}
+	return string(d.src[start:end])
+}
+
+func (d *Document) splice(start int, end int, text string) {
+	next := make([]byte, 0, len(d.src)-(end-start)+len(text))
+	next = append(next, d.src[:start]...)
+	next = append(next, text...)
+	next = append(next, d.src[end:]...)
+	d.src = next
+}
+
+func (d *Document) detectStyle(root object) {
+	d.newline = "\n"
+	if bytes.Contains(d.src, []byte("\r\n")) {
+		d.newline = "\r\n"
+	}
+
+	d.indent = ""
+	for _, line := range strings.Split(string(d.src), "\n") {
+		trimmed := strings.TrimLeft(line, " \t")
+		if trimmed == "" || trimmed == "\r" || len(trimmed) == len(line) {
+			continue
+		}
+		lead := line[:len(line)-len(trimmed)]
+		if lead[0] == '\t' {
+			d.indent = "\t"
+			break
+		}
+		if d.indent == "" || len(lead) < len(d.indent) {
+			d.indent = lead
+		}
+	}
+	// An empty object or one spread over lines with nothing indented gives no
+	// hint, so it gets the npm default.
+	if d.indent == "" && (len(root.members) == 0 || bytes.Count(bytes.TrimSpace(d.src), []byte("\n")) > 0) {
+		d.indent = "  "
+	}
+
+	d.colon = ": "
+	if d.indent == "" {
+		d.colon = ":"
+	}
+	if len(root.members) > 0 {
+		m := root.members[0]
+		d.colon = string(d.src[m.keyEnd:m.valueStart])
+	}
+}
+
+func nestedValue(path []string, value any) any {
+	for i := len(path) - 1; i >= 0; i-- {
+		value = map[string]any{path[i]: value}
+	}
+	return value
+}
+
+func (o object) member(key string) (member, bool) {
+	for _, m := range o.members {
+		if m.key == key {
+			return m, true
+		}
+	}
+	return member{}, false
+}
+
+func (d *Document) root() (object, error) {
+	start := d.skipSpace(0)
+	if start >= len(d.src) || d.src[start] != '{' {
+		return object{}, fmt.Errorf("JSON document is not an object")
+	}
+	return d.scanObject(start)
+}
+
+func (d *Document) scanObject(start int) (object, error) {
+	obj := object{start: start}
+	i := d.skipSpace(start + 1)
+	if i < len(d.src) && d.src[i] == '}' {
+		obj.end = i + 1
+		return obj, nil
+	}
+	for i < len(d.src) {
+		keyEnd, err := d.scanString(i)
+		if err != nil {
+			return object{}, err
+		}
+		var key string
+		if err := json.Unmarshal(d.src[i:keyEnd], &key); err != nil {
+			return object{}, err
+		}
+		colon := d.skipSpace(keyEnd)
+		if colon >= len(d.src) || d.src[colon] != ':' {
+			return object{}, fmt.Errorf("expected ':' at offset %d", colon)
+		}
+		valueStart := d.skipSpace(colon + 1)
+		valueEnd, err := d.scanValue(valueStart)
+		if err != nil {
+			return object{}, err
+		}
+		obj.members = append(obj.members, member{
+			key:        key,
+			keyStart:   i,
+			keyEnd:     keyEnd,
+			valueStart: valueStart,
+			valueEnd:   valueEnd,
+		})
+
+		i = d.skipSpace(valueEnd)
+		if i >= len(d.src) {
+			break
+		}
+		if d.src[i] == '}' {
+			obj.end = i + 1
+			return obj, nil
+		}
+		if d.src[i] != ',' {
+			return object{}, fmt.Errorf("expected ',' or '}' at offset %d", i)
+		}
+		i = d.skipSpace(i + 1)
+	}
+	return object{}, fmt.Errorf("unterminated object at offset %d", start)
+}
+
+func (d *Document) scanValue(i int) (int, error) {
+	if i >= len(d.src) {
+		return 0, fmt.Errorf("unexpected end of JSON")
+	}
+	switch d.src[i] {
+	case '"':
+		return d.scanString(i)
+	case '{':
+		obj, err := d.scanObject(i)
+		if err != nil {
+			return 0, err
+		}
+		return obj.end, nil
+	case '[':
+		return d.scanArray(i)
+	default:
+		end := i
+		for end < len(d.src) && !strings.ContainsRune(",}] \t\r\n", rune(d.src[end])) {
+			end++
+		}
+		return end, nil
+	}
+}
+
+func (d *Document) scanArray(start int) (int, error) {
+	i := d.skipSpace(start + 1)
+	if i < len(d.src) && d.src[i] == ']' {
+		return i + 1, nil
+	}
+	for i < len(d.src) {
+		end, err := d.scanValue(i)
+		if err != nil {
+			return 0, err
+		}
+		i = d.skipSpace(end)
+		if i < len(d.src) && d.src[i] == ']' {
+			return i + 1, nil
+		}
+		if i >= len(d.src) || d.src[i] != ',' {
+			return 0, fmt.Errorf("expected ',' or ']' at offset %d", i)
+		}
+		i = d.skipSpace(i + 1)
+	}
+	return 0, fmt.Errorf("unterminated array at offset %d", start)
+}
+
+func (d *Document) scanString(start int) (int, error) {
+	if start >= len(d.src) || d.src[start] != '"' {
+		return 0, fmt.Errorf("expected string at offset %d", start)
+	}
+	for i := start + 1; i < len(d.src); i++ {
+		switch d.src[i] {
+		case '\\':
+			i++
+		case '"':
+			return i + 1, nil
+		}
+	}
+	return 0, fmt.Errorf("unterminated string at offset %d", start)
+}
+
+func (d *Document) skipSpace(i int) int {
+	for i < len(d.src) {
+		switch d.src[i] {
+		case ' ', '\t', '\r', '\n':
+			i++
+		default:
+			return i
+		}
+	}
+	return i
+}
@@ -0,0 +1,153 @@
+package jsonedit
+
+import "testing"
+
+func TestSetReplacesValueInPlace(t *testing.T) {
+	src := "{\n  \"name\": \"web\",\n  \"version\": \"1.0.0\",\n  \"scripts\": { \"build\": \"vite\" },\n  \"dependencies\": {\n    \"react\": \"^18.0.0\",\n    \"zod\": \"^3.0.0\"\n  }\n}\n"
+	want := "{\n  \"name\": \"web\",\n  \"version\": \"1.0.0\",\n  \"scripts\": { \"build\": \"vite\" },\n  \"dependencies\": {\n    \"react\": \"catalog:\",\n    \"zod\": \"^3.0.0\"\n  }\n}\n"
+
+	assertEdit(t, src, want, func(d *Document) error {
+		return d.Set([]string{"dependencies", "react"}, "catalog:")
+	})
+}
+
+func TestSetAppendsKeysWithDetectedStyle(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		path []string
+		want string
+	}{
+		{
+			name: "tabs and CRLF",
+			src:  "{\r\n\t\"name\": \"web\",\r\n\t\"dependencies\": {\r\n\t\t\"react\": \"^18.0.0\"\r\n\t}\r\n}",
+			path: []string{"dependencies", "zod"},
+			want: "{\r\n\t\"name\": \"web\",\r\n\t\"dependencies\": {\r\n\t\t\"react\": \"^18.0.0\",\r\n\t\t\"zod\": \"catalog:\"\r\n\t}\r\n}",
+		},
+		{
+			name: "four spaces into empty object",
+			src:  "{\n    \"name\": \"web\",\n    \"dependencies\": {}\n}\n",
+			path: []string{"dependencies", "zod"},
+			want: "{\n    \"name\": \"web\",\n    \"dependencies\": {\n        \"zod\": \"catalog:\"\n    }\n}\n",
+		},
+		{
+			name: "missing parent object",
+			src:  "{\n  \"name\": \"web\"\n}\n",
+			path: []string{"catalogs", "react18", "zod"},
+			want: "{\n  \"name\": \"web\",\n  \"catalogs\": {\n    \"react18\": {\n      \"zod\": \"catalog:\"\n    }\n  }\n}\n",
+		},
+		{
+			name: "inline object",
+			src:  "{\n  \"scripts\": { \"build\": \"vite\" }\n}\n",
+			path: []string{"scripts", "zod"},
+			want: "{\n  \"scripts\": { \"build\": \"vite\", \"zod\": \"catalog:\" }\n}\n",
+		},
+		{
+			name: "minified",
+			src:  `{"name":"ui","dependencies":{"react":"^18.0.0"}}`,
+			path: []string{"dependencies", "zod"},
+			want: `{"name":"ui","dependencies":{"react":"^18.0.0","zod":"catalog:"}}`,
+		},
+		{
+			name: "empty document",
+			src:  "",
+			path: []string{"catalog", "zod"},
+			want: "{\n  \"catalog\": {\n    \"zod\": \"catalog:\"\n  }\n}\n",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assertEdit(t, tc.src, tc.want, func(d *Document) error {
+				return d.Set(tc.path, "catalog:")
+			})
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		path []string
+		want string
+	}{
+		{
+			name: "last member",
+			src:  "{\n  \"catalog\": {\n    \"react\": \"^18.0.0\",\n    \"zod\": \"^3.0.0\"\n  }\n}\n",
+			path: []string{"catalog", "zod"},
+			want: "{\n  \"catalog\": {\n    \"react\": \"^18.0.0\"\n  }\n}\n",
+		},
+		{
+			name: "first member",
+			src:  "{\n  \"catalog\": {\n    \"react\": \"^18.0.0\",\n    \"zod\": \"^3.0.0\"\n  }\n}\n",
+			path: []string{"catalog", "react"},
+			want: "{\n  \"catalog\": {\n    \"zod\": \"^3.0.0\"\n  }\n}\n",
+		},
+		{
+			name: "only member",
+			src:  "{\n  \"name\": \"web\",\n  \"catalog\": {\n    \"zod\": \"^3.0.0\"\n  }\n}\n",
+			path: []string{"catalog", "zod"},
+			want: "{\n  \"name\": \"web\",\n  \"catalog\": {}\n}\n",
+		},
+		{
+			name: "whole key",
+			src:  "{\n  \"name\": \"web\",\n  \"catalog\": {\n    \"zod\": \"^3.0.0\"\n  }\n}\n",
+			path: []string{"catalog"},
+			want: "{\n  \"name\": \"web\"\n}\n",
+		},
+		{
+			name: "missing key",
+			src:  "{\n  \"name\": \"web\"\n}\n",
+			path: []string{"catalog", "zod"},
+			want: "{\n  \"name\": \"web\"\n}\n",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assertEdit(t, tc.src, tc.want, func(d *Document) error {
+				return d.Delete(tc.path)
+			})
+		})
+	}
+}
+
+func TestRenameKeepsValueInPlace(t *testing.T) {
+	src := "{\n  \"catalogs\": {\n    \"legacy\": {\n      \"react\": \"^17.0.2\"\n    },\n    \"tooling\": {}\n  }\n}\n"
+	want := "{\n  \"catalogs\": {\n    \"react17\": {\n      \"react\": \"^17.0.2\"\n    },\n    \"tooling\": {}\n  }\n}\n"
+	assertEdit(t, src, want, func(d *Document) error {
+		return d.Rename([]string{"catalogs", "legacy"}, "react17")
+	})
+
+	doc, err := Parse([]byte(src))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if err := doc.Rename([]string{"catalogs", "legacy"}, "tooling"); err == nil {
+		t.Fatal("Rename() onto existing key error = nil, want non-nil")
+	}
+}
+
+func TestParseRejectsNonObjects(t *testing.T) {
+	if _, err := Parse([]byte(`["a"]`)); err == nil {
+		t.Fatal("Parse() error = nil, want non-nil")
+	}
+	if _, err := Parse([]byte(`{"a":`)); err == nil {
+		t.Fatal("Parse() error = nil, want non-nil")
+	}
+}
+
+func assertEdit(t *testing.T, src string, want string, edit func(*Document) error) {
+	t.Helper()
+	doc, err := Parse([]byte(src))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if err := edit(doc); err != nil {
+		t.Fatalf("edit error = %v", err)
+	}
+	if got := string(doc.Bytes()); got != want {
+		t.Fatalf("document =\n%q\nwant\n%q", got, want)
+	}
+}
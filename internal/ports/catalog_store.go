@@ -9,6 +9,13 @@ import (
 type CatalogStore interface {
 	UpsertCatalogEntries(ctx context.Context, manager domain.PackageManager, name string, entries map[string]string, force bool) error
 	RemoveCatalogEntries(ctx context.Context, manager domain.PackageManager, name string, packages []string) error
+	// RenameCatalog renames a named catalog, keeping its entries.
+	RenameCatalog(ctx context.Context, manager domain.PackageManager, oldName string, newName string) error
+	// DeleteCatalog removes a named catalog and its entries.
+	DeleteCatalog(ctx context.Context, manager domain.PackageManager, name string) error
 	NamedCatalogs(ctx context.Context, manager domain.PackageManager) ([]string, error)
 	CatalogPackageNames(ctx context.Context, manager domain.PackageManager, name string) ([]string, error)
+	// CatalogEntries returns package names mapped to version ranges for the
+	// default catalog (empty name) or a named catalog.
+	CatalogEntries(ctx context.Context, manager domain.PackageManager, name string) (map[string]string, error)
 }
@@ -8,3 +8,9 @@ type ConfigStore interface {
 	ReadFile(path string) ([]byte, error)
 	WriteFile(path string, data []byte, perm os.FileMode) error
 }
+
+// ChangeRecorder receives file writes that a dry run kept off disk. Before is
+// nil when the file did not exist.
+type ChangeRecorder interface {
+	RecordFile(path string, before []byte, after []byte)
+}
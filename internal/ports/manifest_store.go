@@ -1,8 +1,18 @@
 package ports
 
-import "context"
+import (
+	"context"
+
+	"ordo/internal/domain"
+)
 
 type ManifestStore interface {
 	RewriteCatalogReferences(ctx context.Context, targetDir string, catalogName string, packages []string) error
 	RewriteCatalogReferencesExistingOnly(ctx context.Context, targetDir string, catalogName string, packages []string) error
+	// RewriteSectionCatalogReferences is RewriteCatalogReferences limited to
+	// one dependency section; missing packages are added to that section.
+	RewriteSectionCatalogReferences(ctx context.Context, targetDir string, section domain.PresetBucket, catalogName string, packages []string) error
+	RewriteSectionCatalogReferencesExistingOnly(ctx context.Context, targetDir string, section domain.PresetBucket, catalogName string, packages []string) error
+	InlineCatalogReferences(ctx context.Context, targetDir string, versions map[string]map[string]string) error
+	RenameCatalogReferences(ctx context.Context, targetDir string, oldName string, newName string) error
 }
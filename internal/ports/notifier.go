@@ -0,0 +1,6 @@
+package ports
+
+// Notifier surfaces non-fatal warnings to the user.
+type Notifier interface {
+	Warn(message string)
+}
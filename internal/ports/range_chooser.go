@@ -0,0 +1,9 @@
+package ports
+
+import "context"
+
+// RangeChooser asks the user to pick a version range for a package. An empty
+// choice skips the package.
+type RangeChooser interface {
+	ChooseRange(ctx context.Context, pkg string, options []string, proposed string) (string, error)
+}
@@ -5,3 +5,16 @@ import "context"
 type Runner interface {
 	Run(ctx context.Context, dir string, argv []string) error
 }
+
+// StreamRunner runs a command while prefixing each line it writes, so output from
+// concurrent commands stays readable.
+type StreamRunner interface {
+	RunPrefixed(ctx context.Context, dir string, argv []string, prefix StreamPrefix) error
+}
+
+// StreamPrefix holds the line prefix for a command's stdout and stderr, which
+// differ when only one of the streams shows color.
+type StreamPrefix struct {
+	Stdout string
+	Stderr string
+}
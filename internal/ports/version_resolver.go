@@ -1,7 +1,12 @@
 package ports
 
-import "context"
+import (
+	"context"
+
+	"ordo/internal/domain"
+)
 
 type PackageVersionResolver interface {
 	LatestVersion(ctx context.Context, packageName string) (string, error)
+	PackageMetadata(ctx context.Context, packageName string) (domain.PackageMetadata, error)
 }